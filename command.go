@@ -0,0 +1,409 @@
+package saddle
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"gopkg.in/yaml.v3"
+)
+
+const (
+	formatJSON = "json"
+	formatYAML = "yaml"
+)
+
+type (
+	// registration contains a service instantiated within the executable; referenced by the config subcommand(s).
+	registration struct {
+		// flags contains the service specific flag(s) of the command of the service (see Command).
+		flags   []*serviceFlag
+		options *options
+		service Service
+	}
+
+	// discard contains a flag value discarding whatever it is set to; used to parse argument(s) without side effects.
+	discard struct{}
+
+	// printed contains the effective configuration of a service along with the source of each key.
+	printed struct {
+		Config  map[string]any    `json:"config" yaml:"config"`
+		Sources map[string]string `json:"sources" yaml:"sources"`
+	}
+)
+
+// registrations contains the service(s) instantiated within the executable, keyed by name.
+var registrations = map[string]*registration{}
+
+// register registers the referenced service so it is available to the config subcommand(s).
+func register(service Service, options *options) {
+	registrations[service.Name()] = &registration{
+		options: options,
+		service: service,
+	}
+}
+
+// registerFlags records the service specific flag(s) of the command of the referenced service so the config
+// subcommand(s) accept them; ignored unless the service is registered (see Join).
+func registerFlags(service string, flags []*serviceFlag) {
+	if r, ok := registrations[service]; ok {
+		r.flags = flags
+	}
+}
+
+func (discard) Set(string) error { return nil }
+func (discard) String() string   { return "" }
+func (discard) Type() string     { return "string" }
+
+// lookup returns the registration of the referenced service name.
+func lookup(name string) (*registration, error) {
+	r, ok := registrations[name]
+	if !ok {
+		names := make([]string, 0, len(registrations))
+		for n := range registrations {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		return nil, fmt.Errorf("unknown service %q; expected one of: %s", name, strings.Join(names, ", "))
+	}
+	return r, nil
+}
+
+// loader constructs a loader of the registered service for the referenced environment, (optional) explicit
+// configuration file and (optional) search path(s); the search path(s) of the service are used when none are
+// referenced.
+func (r *registration) loader(environment, file string, paths []string) *loader {
+	fs := pflag.NewFlagSet(r.service.Name(), pflag.ContinueOnError)
+	defineStandardFlags(fs)
+	_ = fs.Set(environmentFlag, environment)
+	if file != "" {
+		_ = fs.Set(configFlag, file)
+	}
+	if len(paths) > 0 {
+		_ = fs.Set(configPathFlag, strings.Join(paths, ","))
+	}
+	return newLoader(r.service.Name(), fs, r.options)
+}
+
+// flagSet constructs a flag set mirroring the command of the registered service (see Command): the standard flag(s),
+// --address and the service specific flag(s). The environment | address are seeded from their environment variable(s).
+func (r *registration) flagSet() (*pflag.FlagSet, error) {
+	fs := pflag.NewFlagSet(r.service.Name(), pflag.ContinueOnError)
+	defineStandardFlags(fs)
+	fs.StringP(addressFlag, "a", "", addressUsage)
+	for _, f := range []string{environmentFlag, addressFlag} {
+		if v := os.Getenv(strings.ToUpper(f)); v != "" {
+			_ = fs.Set(f, v)
+		}
+	}
+
+	for _, sf := range r.flags {
+		if err := defineFlag(fs, r.service.Config(), sf); err != nil {
+			return nil, err
+		}
+	}
+	return fs, nil
+}
+
+// parseServiceArgs parses the referenced argument(s) of a config subcommand accepting the flag(s) of the command of the
+// service referenced by its sole positional argument; the parsed flag set, a superset of the flag set of the service
+// command, is returned so the configuration is loaded exactly as the service would load it. pflag.ErrHelp is returned
+// when help is requested.
+func parseServiceArgs(cmd *cobra.Command, args []string) (*registration, *pflag.FlagSet, error) {
+	// - locate the service; the flag(s) of every registered service are known so a flag value is never mistaken for
+	// the service ↴
+	probe := pflag.NewFlagSet(cmd.Name(), pflag.ContinueOnError)
+	probe.SetOutput(io.Discard)
+	probe.ParseErrorsWhitelist.UnknownFlags = true
+	sets := []*pflag.FlagSet{cmd.Flags()}
+	for _, r := range registrations {
+		fs, err := r.flagSet()
+		if err != nil {
+			return nil, nil, err
+		}
+		sets = append(sets, fs)
+	}
+	for _, fs := range sets {
+		fs.VisitAll(func(f *pflag.Flag) {
+			if f.Name == "help" || probe.Lookup(f.Name) != nil {
+				return
+			}
+			shorthand := f.Shorthand
+			if probe.ShorthandLookup(shorthand) != nil {
+				shorthand = ""
+			}
+			probe.VarPF(discard{}, f.Name, shorthand, "").NoOptDefVal = f.NoOptDefVal
+		})
+	}
+	if err := probe.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	if probe.NArg() != 1 {
+		return nil, nil, fmt.Errorf("accepts 1 arg(s), received %d", probe.NArg())
+	}
+	r, err := lookup(probe.Arg(0))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// - parse the flag(s) of the subcommand along with those of the service command ↴
+	sf, err := r.flagSet()
+	if err != nil {
+		return nil, nil, err
+	}
+	fs := pflag.NewFlagSet(cmd.Name(), pflag.ContinueOnError)
+	fs.SetOutput(io.Discard)
+	for _, set := range []*pflag.FlagSet{cmd.Flags(), sf} {
+		set.VisitAll(func(f *pflag.Flag) {
+			if f.Name == "help" {
+				return
+			}
+			if fs.Lookup(f.Name) != nil || f.Shorthand != "" && fs.ShorthandLookup(f.Shorthand) != nil {
+				err = fmt.Errorf("flag --%s of %s conflicts with a flag of %s", f.Name, r.service.Name(),
+					cmd.CommandPath())
+				return
+			}
+			fs.AddFlag(f)
+		})
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := fs.Parse(args); err != nil {
+		return nil, nil, err
+	}
+	return r, fs, nil
+}
+
+// unset removes the referenced dotted key from the referenced (nested) settings, along with any parent left empty.
+func unset(settings map[string]any, key string) {
+	head, rest, nested := strings.Cut(key, ".")
+	if !nested {
+		delete(settings, head)
+		return
+	}
+	if m, ok := settings[head].(map[string]any); ok {
+		unset(m, rest)
+		if len(m) == 0 {
+			delete(settings, head)
+		}
+	}
+}
+
+// configCommand constructs the config command, grouping configuration related subcommand(s).
+func configCommand() *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "config",
+		Short: "inspect service configuration(s)",
+	}
+	cmd.AddCommand(configPrintCommand())
+	cmd.AddCommand(configValidateCommand())
+	cmd.AddCommand(configSchemaCommand())
+	return cmd
+}
+
+// configPrintCommand constructs the config print command; prints the effective configuration a service would load,
+// with secret value(s) redacted. The flag(s) of the service command are accepted (e.g. --config-path and service
+// specific flag(s)) so the configuration is loaded exactly as the service would load it.
+func configPrintCommand() *cobra.Command {
+	var (
+		format   string
+		patterns []string
+		sources  bool
+	)
+
+	cmd := &cobra.Command{
+		Use:   "print <service>",
+		Short: "print the effective configuration of a service with secrets redacted",
+		Long: "print the effective configuration of a service with secrets redacted; the flag(s) of the service " +
+			"command (--environment, --config, --config-path, --address and service specific flag(s)) are accepted",
+		// flag(s) are parsed once the service is known so the flag(s) of its command are accepted
+		DisableFlagParsing: true,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, fs, err := parseServiceArgs(cmd, args)
+			if errors.Is(err, pflag.ErrHelp) {
+				return cmd.Help()
+			}
+			if err != nil {
+				return err
+			}
+			if format != formatYAML && format != formatJSON {
+				return fmt.Errorf("unsupported format %q; expected %s or %s", format, formatYAML, formatJSON)
+			}
+			if env, _ := fs.GetString(environmentFlag); env == "" {
+				return fmt.Errorf("required flag(s) %q not set", environmentFlag)
+			}
+			cmd.SilenceUsage = true
+
+			sc, err := newConfig(r.service)
+			if err != nil {
+				return err
+			}
+			name := r.service.Name()
+			l := newLoader(name, fs, r.options)
+			ld, err := l.load(sc)
+			if err != nil {
+				return err
+			}
+
+			keys := secretKeys(ld.config, l.sectioned(sc))
+			for k := range ld.secrets {
+				keys = append(keys, k)
+			}
+			rd, err := newRedactor(keys, append(r.options.redactPatterns, patterns...)...)
+			if err != nil {
+				return err
+			}
+			settings := ld.viper.AllSettings()
+			rd.redact(settings, "")
+
+			// - drop the flag(s) bound beneath the service name (e.g. <name>.environment); bookkeeping of the loader
+			// rather than configuration ↴
+			fs.VisitAll(func(f *pflag.Flag) {
+				if key := boundKey(name, f); key == flagKey(name, f.Name) {
+					unset(settings, key)
+					delete(ld.provenance, key)
+				}
+			})
+
+			var out any = settings
+			if sources {
+				p := printed{
+					Config:  settings,
+					Sources: map[string]string{},
+				}
+				for _, k := range ld.provenance.Keys() {
+					p.Sources[k] = ld.provenance[k].String()
+				}
+				out = p
+			}
+			return write(cmd.OutOrStdout(), format, out)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "o", formatYAML, "output format (yaml | json)")
+	cmd.Flags().StringSliceVar(&patterns, "redact", nil, "additional key pattern(s) (regular expressions) to redact")
+	cmd.Flags().BoolVar(&sources, "sources", false, "include the source of each configuration key")
+	return cmd
+}
+
+// configValidateCommand constructs the config validate command; validates the saddle and service configuration(s) of
+// every environment within the embedded file system or search path(s) without starting the service(s).
+func configValidateCommand() *cobra.Command {
+	var (
+		file  string
+		paths []string
+	)
+
+	cmd := &cobra.Command{
+		Use:   "validate [service...]",
+		Short: "validate the configuration of every environment for the referenced (or all) service(s)",
+		RunE: func(cmd *cobra.Command, args []string) error {
+			if len(args) == 0 {
+				for n := range registrations {
+					args = append(args, n)
+				}
+				sort.Strings(args)
+			}
+
+			var rs []*registration
+			for _, n := range args {
+				r, err := lookup(n)
+				if err != nil {
+					return err
+				}
+				rs = append(rs, r)
+			}
+			cmd.SilenceUsage = true
+
+			var failed, total int
+			w := cmd.OutOrStdout()
+			for _, r := range rs {
+				name := r.service.Name()
+				l := r.loader("", file, paths)
+				envs, err := l.environments()
+				if err != nil {
+					return err
+				}
+				if len(envs) == 0 {
+					return fmt.Errorf("no environment configuration file(s) found for %s within: %s", name,
+						strings.Join(l.paths, ", "))
+				}
+
+				for _, env := range envs {
+					total++
+					sc, err := newConfig(r.service)
+					if err == nil {
+						_, err = r.loader(env, file, paths).load(sc)
+					}
+					if err != nil {
+						failed++
+						fmt.Fprintf(w, "FAIL %s [%s]\n  %s\n", name, env, strings.ReplaceAll(err.Error(), "\n", "\n  "))
+						continue
+					}
+					fmt.Fprintf(w, "ok   %s [%s]\n", name, env)
+				}
+			}
+
+			if failed > 0 {
+				return fmt.Errorf("%d of %d configuration(s) invalid", failed, total)
+			}
+			return nil
+		},
+	}
+
+	cmd.Flags().StringVarP(&file, configFlag, "c", "", "explicit configuration file merged above the search path(s)")
+	cmd.Flags().StringSliceVar(&paths, configPathFlag, nil, "ordered path(s) searched for configuration file(s)")
+	return cmd
+}
+
+// configSchemaCommand constructs the config schema command; prints the JSON Schema of the saddle and service
+// configuration(s) so editors | linters can validate configuration file(s) without running the service.
+func configSchemaCommand() *cobra.Command {
+	var format string
+
+	cmd := &cobra.Command{
+		Use:   "schema <service>",
+		Short: "print the JSON Schema of the configuration of a service",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			r, err := lookup(args[0])
+			if err != nil {
+				return err
+			}
+			if format != formatYAML && format != formatJSON {
+				return fmt.Errorf("unsupported format %q; expected %s or %s", format, formatYAML, formatJSON)
+			}
+			cmd.SilenceUsage = true
+
+			s, err := NewSchema(r.service)
+			if err != nil {
+				return err
+			}
+			return write(cmd.OutOrStdout(), format, s)
+		},
+	}
+
+	cmd.Flags().StringVarP(&format, "format", "o", formatJSON, "output format (yaml | json)")
+	return cmd
+}
+
+// write writes the referenced value to the writer in the referenced format.
+func write(w io.Writer, format string, v any) error {
+	if format == formatJSON {
+		e := json.NewEncoder(w)
+		e.SetIndent("", "  ")
+		return e.Encode(v)
+	}
+
+	e := yaml.NewEncoder(w)
+	e.SetIndent(2)
+	defer e.Close()
+	return e.Encode(v)
+}
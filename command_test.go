@@ -0,0 +1,240 @@
+package saddle
+
+import (
+	"bytes"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+
+	"gopkg.in/yaml.v3"
+)
+
+type (
+	// printConfig contains the service configuration printed by test(s).
+	printConfig struct {
+		Name     string `mapstructure:"name"`
+		Password string `mapstructure:"password"`
+		Key      string `mapstructure:"key" secret:"true"`
+		Internal string `mapstructure:"internal"`
+		Limit    int    `mapstructure:"limit"`
+	}
+)
+
+// registerService registers the referenced service, along with its service specific flag(s), for the duration of the
+// test.
+func registerService(t *testing.T, service Service, options *options, flags ...*serviceFlag) {
+	t.Helper()
+
+	register(service, options)
+	registerFlags(service.Name(), flags)
+	t.Cleanup(func() { delete(registrations, service.Name()) })
+}
+
+// executeConfig executes the config command with the referenced argument(s), returning its output.
+func executeConfig(args ...string) (string, error) {
+	out := &bytes.Buffer{}
+	cmd := configCommand()
+	cmd.SetArgs(args)
+	cmd.SetOut(out)
+	cmd.SetErr(io.Discard)
+	err := cmd.Execute()
+	return out.String(), err
+}
+
+func TestConfigPrint(t *testing.T) {
+	dir := t.TempDir()
+	config := `
+name: file
+password: hunter2
+key: s3cr3t
+internal: visible
+limit: 1`
+	if err := os.WriteFile(filepath.Join(dir, "test.yaml"), []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	t.Setenv("ENVIRONMENT", "")
+
+	// the search path(s) of the service are empty; configuration is only found through --config-path
+	registerService(t, &testService{config: &printConfig{}}, newOptions(WithSearchPaths(t.TempDir())),
+		&serviceFlag{key: "limit", name: "limit"})
+
+	tests := []struct {
+		name        string
+		args        []string
+		want        map[string]any
+		wantSources map[string]string
+		wantErr     string
+	}{
+		{
+			// password matches the default pattern; key is tagged secret:"true"
+			name: "redacts secrets",
+			args: []string{"svc", "-e", "test", "--config-path", dir},
+			want: map[string]any{"name": "file", "password": redactedValue, "key": redactedValue,
+				"internal": "visible", "limit": 1},
+		},
+		{
+			name: "redacts additional patterns",
+			args: []string{"svc", "-e", "test", "--config-path", dir, "--redact", "^internal$"},
+			want: map[string]any{"name": "file", "password": redactedValue, "key": redactedValue,
+				"internal": redactedValue, "limit": 1},
+		},
+		{
+			// flag(s) of the service command may precede the service
+			name: "applies service flags",
+			args: []string{"-e", "test", "--limit", "5", "--config-path", dir, "svc", "--sources"},
+			want: map[string]any{"name": "file", "password": redactedValue, "key": redactedValue,
+				"internal": "visible", "limit": 5},
+			wantSources: map[string]string{
+				"internal": "environment file (" + filepath.Join(dir, "test.yaml") + ")",
+				"limit":    "flag (--limit)",
+			},
+		},
+		{
+			name:    "requires an environment",
+			args:    []string{"svc", "--config-path", dir},
+			wantErr: `required flag(s) "environment" not set`,
+		},
+		{
+			name:    "rejects unknown flags",
+			args:    []string{"svc", "-e", "test", "--unknown"},
+			wantErr: "unknown flag: --unknown",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeConfig(append([]string{"print"}, tt.args...)...)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v; want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("print: %v", err)
+			}
+
+			var got map[string]any
+			var sources map[string]string
+			if tt.wantSources != nil {
+				var p struct {
+					Config  map[string]any    `yaml:"config"`
+					Sources map[string]string `yaml:"sources"`
+				}
+				if err := yaml.Unmarshal([]byte(out), &p); err != nil {
+					t.Fatalf("unmarshal: %v", err)
+				}
+				got, sources = p.Config, p.Sources
+			} else if err := yaml.Unmarshal([]byte(out), &got); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+
+			// the flag(s) bound beneath the service name are not configuration
+			if _, ok := got["svc"]; ok {
+				t.Errorf("printed flag key(s) svc: %v", got["svc"])
+			}
+			delete(got, "saddle")
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("config = %v; want %v", got, tt.want)
+			}
+			for k, v := range tt.wantSources {
+				if sources[k] != v {
+					t.Errorf("source of %s = %q; want %q", k, sources[k], v)
+				}
+			}
+			for k := range sources {
+				if strings.HasPrefix(k, "svc.") {
+					t.Errorf("printed source of flag key %s", k)
+				}
+			}
+		})
+	}
+}
+
+func TestConfigValidate(t *testing.T) {
+	write := func(dir string, files map[string]string) string {
+		for name, data := range files {
+			if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+				t.Fatal(err)
+			}
+		}
+		return dir
+	}
+	valid := write(t.TempDir(), map[string]string{"test.yaml": `name: svc`})
+	mixed := write(t.TempDir(), map[string]string{
+		"test.yaml":       `name: svc`,
+		"broken.yaml":     `saddle: {shutdown: {drain_timeout: never}}`,
+		"default.yaml":    `name: default`,
+		"test.local.yaml": `name: local`,
+	})
+	override := write(t.TempDir(), map[string]string{"override.yaml": `saddle: {server: {socket_mode: 0999}}`})
+
+	// the search path(s) of the service are empty; configuration is only found through --config-path
+	registerService(t, &testService{}, newOptions(WithSearchPaths(t.TempDir())))
+
+	tests := []struct {
+		name    string
+		args    []string
+		want    []string
+		wantErr string
+	}{
+		{
+			name: "valid",
+			args: []string{"--config-path", valid},
+			want: []string{"ok   svc [test]"},
+		},
+		{
+			// default and local override file(s) are layers rather than environment(s)
+			name:    "invalid environment",
+			args:    []string{"--config-path", mixed},
+			want:    []string{"FAIL svc [broken]", "- saddle.shutdown.drain_timeout", "ok   svc [test]"},
+			wantErr: "1 of 2 configuration(s) invalid",
+		},
+		{
+			name:    "invalid explicit configuration file",
+			args:    []string{"--config-path", valid, "--config", filepath.Join(override, "override.yaml")},
+			want:    []string{"FAIL svc [test]", "- saddle.server.socket_mode"},
+			wantErr: "1 of 1 configuration(s) invalid",
+		},
+		{
+			name:    "no environments",
+			args:    []string{"--config-path", t.TempDir()},
+			wantErr: "no environment configuration file(s) found for svc",
+		},
+		{
+			name:    "unknown service",
+			args:    []string{"other"},
+			wantErr: `unknown service "other"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out, err := executeConfig(append([]string{"validate", "svc"}, tt.args...)...)
+			if tt.wantErr == "" && err != nil {
+				t.Fatalf("validate: %v", err)
+			}
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v; want %q", err, tt.wantErr)
+				}
+				// the process exits non-zero
+				if ExitCode(err) == 0 {
+					t.Errorf("exit code = 0; want non-zero")
+				}
+			}
+
+			for _, line := range tt.want {
+				if !strings.Contains(out, line) {
+					t.Errorf("output does not contain %q:\n%s", line, out)
+				}
+			}
+			if strings.Contains(out, "[default]") || strings.Contains(out, "[test.local]") {
+				t.Errorf("output validates a layer as an environment:\n%s", out)
+			}
+		})
+	}
+}
@@ -0,0 +1,403 @@
+package saddle
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"sort"
+	"strings"
+
+	"github.com/spf13/pflag"
+	"github.com/spf13/viper"
+
+	"github.com/captjt/saddle/models"
+)
+
+const (
+	// configFolder contains the path searched for configuration file(s) when the service does not reference any.
+	configFolder = ".config"
+	// defaultConfigName contains the name of the configuration file merged beneath every environment.
+	defaultConfigName = "default"
+	// localConfigSuffix contains the suffix of the (git-ignored) configuration file merged above an environment.
+	localConfigSuffix = ".local"
+
+	// environmentFlag contains the name of the flag referencing the environment of service deployment.
+	environmentFlag = "environment"
+	// addressFlag contains the name of the flag referencing the address | interface to listen for incoming requests.
+	addressFlag = "address"
+	// addressUsage contains the usage of the flag referencing the address | interface to listen for incoming requests.
+	addressUsage = "address | interface to listen for incoming requests (host:port, unix://<path> | fd://<fd|name>)"
+	// adminAddressKey contains the configuration key, beneath the service name, of the admin address of a service (e.g.
+	// <name>.admin.address); overrides saddle.admin.address so the member(s) of a group listen on distinct addresses.
+	adminAddressKey = "admin.address"
+	// configFlag contains the name of the flag referencing an explicit configuration file.
+	configFlag = "config"
+	// configPathFlag contains the name of the flag referencing the ordered configuration search path(s).
+	configPathFlag = "config-path"
+)
+
+type (
+	// loader loads the layered configuration of a service; every load uses an isolated viper instance so services (and
+	// reload(s) of a service) never share configuration state.
+	loader struct {
+		environment string
+		// file contains the explicit configuration file merged above the file(s) within the search path(s).
+		file    string
+		flags   *pflag.FlagSet
+		options *options
+		// paths contains the ordered, expanded path(s) searched for configuration file(s).
+		paths   []string
+		service string
+	}
+
+	// layer contains a configuration file merged into the configuration of a service.
+	layer struct {
+		name string
+		kind SourceKind
+		// environment reports whether the layer provides the configuration of the environment.
+		environment bool
+	}
+
+	// loaded contains the result of loading the layered configuration of a service.
+	loaded struct {
+		config     *models.Config
+		provenance Provenance
+		// secrets contains the resolved secret reference(s), keyed by configuration key.
+		secrets map[string]string
+		viper   *viper.Viper
+	}
+)
+
+// newLoader constructs a new loader of the referenced service; the environment, explicit configuration file and search
+// path(s) are resolved from the bound flag(s) | environment variable(s) of the service.
+func newLoader(service string, flags *pflag.FlagSet, options *options) *loader {
+	l := &loader{
+		flags:   flags,
+		options: options,
+		service: service,
+	}
+
+	vp := l.viper()
+	l.environment = vp.GetString(flagKey(service, environmentFlag))
+	l.file = vp.GetString(flagKey(service, configFlag))
+
+	paths := options.searchPaths
+	if p := vp.GetStringSlice(flagKey(service, configPathFlag)); len(p) > 0 {
+		paths = p
+	}
+	for _, p := range paths {
+		l.paths = append(l.paths, os.ExpandEnv(p))
+	}
+	return l
+}
+
+// viper constructs a new viper instance with the environment variable and flag binding(s) of the service.
+func (l *loader) viper() *viper.Viper {
+	vp := viper.New()
+	vp.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
+	vp.SetEnvPrefix(l.options.envPrefix)
+	vp.AutomaticEnv()
+
+	// - bind flag(s) beneath the service name (e.g. --environment ⇢ <name>.environment); service specific flag(s)
+	// are bound to their configuration key (see WithFlag) ↴
+	if l.flags != nil {
+		l.flags.VisitAll(func(f *pflag.Flag) {
+			_ = vp.BindPFlag(boundKey(l.service, f), f)
+		})
+	}
+	return vp
+}
+
+// envVar returns the environment variable in which the referenced configuration key is bound.
+func (l *loader) envVar(key string) string {
+	ev := strings.ToUpper(strings.ReplaceAll(key, ".", "_"))
+	if l.options.envPrefix != "" {
+		ev = strings.ToUpper(l.options.envPrefix) + "_" + ev
+	}
+	return ev
+}
+
+// load loads, deserializes and validates the saddle and service configuration(s) of the environment; any problem(s)
+// are returned as a *ConfigError. Configuration is merged deterministically, each source taking precedence over the
+// former: `default:"..."` struct tags, embedded default.* and <env>.* (see WithFS), default.*, <env>.* and
+// <env>.local.* within the search path(s), the --config file, environment variables, then flags. The source of each key
+// is recorded within the loaded Provenance. Secret reference(s) (file://, env:// and base64://) are resolved before the
+// service configuration is deserialized into the referenced target (e.g. service.Config()) from the configuration
+// section of the service (see WithConfigSection).
+func (l *loader) load(target any) (*loaded, error) {
+	vp := l.viper()
+	st := l.sectioned(target)
+
+	ce := &ConfigError{
+		Path:        strings.Join(l.paths, ", "),
+		Environment: l.environment,
+	}
+	pv := Provenance{}
+
+	// - handle import | merge of configuration file(s); set by referenced environment ↴
+	var layers []layer
+	if l.options.fsys != nil {
+		layers = append(layers,
+			layer{name: defaultConfigName, kind: SourceEmbeddedFile},
+			layer{name: l.environment, kind: SourceEmbeddedFile, environment: true},
+		)
+	}
+	layers = append(layers,
+		layer{name: defaultConfigName, kind: SourceDefaultFile},
+		layer{name: l.environment, kind: SourceEnvironmentFile, environment: true},
+		layer{name: l.environment + localConfigSuffix, kind: SourceLocalFile},
+	)
+	if l.file != "" {
+		layers = append(layers, layer{name: l.file, kind: SourceConfigFile, environment: true})
+	}
+
+	var found bool
+	for _, ly := range layers {
+		f, path, err := l.read(ly)
+		if err != nil {
+			if path != "" {
+				ce.Path = path
+			}
+			ce.Err = err
+			return nil, ce
+		}
+		if f == nil {
+			continue
+		}
+		if err := vp.MergeConfigMap(f.AllSettings()); err != nil {
+			ce.Path, ce.Err = path, err
+			return nil, ce
+		}
+		if ly.environment {
+			ce.Path, found = path, true
+		}
+		pv.record(Source{Kind: ly.kind, Name: path}, f.AllKeys()...)
+	}
+	if !found {
+		ce.Err = fmt.Errorf("no %s configuration file found within the embedded file system or search path(s)",
+			l.environment)
+		return nil, ce
+	}
+
+	// - apply struct tag default(s) beneath every other source ↴
+	for _, c := range []any{&models.Config{}, st} {
+		for k, d := range structDefaults(reflect.TypeOf(c), "", vp.IsSet) {
+			vp.SetDefault(k, d)
+			if _, ok := pv[k]; !ok {
+				pv.record(Source{Kind: SourceStructDefault, Name: fmt.Sprintf("%s:%q", defaultTag, d)}, k)
+			}
+		}
+
+		// automatic environment binding only applies to key(s) set by another source; bind the remainder explicitly
+		for _, k := range structKeys(reflect.TypeOf(c), "") {
+			_ = vp.BindEnv(k)
+		}
+	}
+
+	// - record keys overridden by environment variable(s) | flag(s) ↴
+	for _, k := range vp.AllKeys() {
+		if ev := l.envVar(k); os.Getenv(ev) != "" {
+			pv.record(Source{Kind: SourceEnvVar, Name: ev}, k)
+		}
+	}
+	if l.flags != nil {
+		l.flags.Visit(func(f *pflag.Flag) {
+			pv.record(Source{Kind: SourceFlag, Name: "--" + f.Name}, boundKey(l.service, f))
+		})
+	}
+
+	// - resolve secret reference(s) prior to deserialization ↴
+	secrets := resolveSecrets(vp, ce)
+
+	v := newValidator()
+
+	// - deserialize | validate saddle configuration(s); the saddle configuration of the section of the service takes
+	// precedence over the shared saddle configuration ↴
+	hc := &models.Config{}
+	err := vp.Unmarshal(hc)
+	if err == nil && l.options.section != "" {
+		err = vp.Unmarshal(nest(hc, l.options.section))
+		prefix := l.options.section + "."
+		for _, k := range pv.Keys() {
+			if strings.HasPrefix(k, prefix+"saddle.") {
+				pv.record(pv[k], strings.TrimPrefix(k, prefix))
+			}
+		}
+	}
+	if err != nil {
+		ce.appendProblems(err)
+	} else if err := v.Struct(hc); err != nil {
+		ce.appendProblems(err)
+	}
+
+	// - deserialize | validate service configuration(s) ↴
+	if err := vp.Unmarshal(st); err != nil {
+		ce.appendProblems(err)
+	} else if err := v.Struct(target); err != nil {
+		n := len(ce.Problems)
+		ce.appendProblems(err)
+		// validation problem(s) are keyed relative to the service configuration; key them beneath its section
+		for _, p := range ce.Problems[n:] {
+			p.Key = joinKey(l.options.section, p.Key)
+		}
+	}
+
+	if len(ce.Problems) > 0 {
+		// deserialization problem(s) may quote the offending value; never report a resolved secret
+		for _, p := range ce.Problems {
+			for _, sv := range secrets {
+				if sv != "" {
+					p.Message = strings.ReplaceAll(p.Message, sv, redactedValue)
+				}
+			}
+		}
+		return nil, ce
+	}
+	return &loaded{
+		config:     hc,
+		provenance: pv,
+		secrets:    secrets,
+		viper:      vp,
+	}, nil
+}
+
+// read reads the referenced configuration layer; a nil viper instance is returned when an optional layer is not found.
+// The path of the file read (if any) is returned for reporting, embedded file(s) being prefixed with embed:.
+func (l *loader) read(ly layer) (*viper.Viper, string, error) {
+	f := viper.New()
+
+	switch ly.kind {
+	case SourceConfigFile:
+		// an explicitly referenced file must exist
+		f.SetConfigFile(ly.name)
+		return f, ly.name, f.ReadInConfig()
+	case SourceEmbeddedFile:
+		for _, ext := range viper.SupportedExts {
+			name := fmt.Sprintf("%s.%s", ly.name, ext)
+			b, err := fs.ReadFile(l.options.fsys, name)
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			if err != nil {
+				return nil, "embed:" + name, err
+			}
+			f.SetConfigType(ext)
+			return f, "embed:" + name, f.ReadConfig(bytes.NewReader(b))
+		}
+		return nil, "", nil
+	}
+
+	f.SetConfigName(ly.name)
+	for _, p := range l.paths {
+		f.AddConfigPath(p)
+	}
+	if err := f.ReadInConfig(); err != nil {
+		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
+			return nil, "", nil
+		}
+		return nil, f.ConfigFileUsed(), err
+	}
+	return f, f.ConfigFileUsed(), nil
+}
+
+// sectioned returns the referenced target nested beneath the configuration section of the service (see
+// WithConfigSection); the target itself when the service configuration is deserialized from the root.
+func (l *loader) sectioned(target any) any {
+	if l.options.section == "" {
+		return target
+	}
+	return nest(target, l.options.section)
+}
+
+// nest returns a new struct nesting the referenced target (a pointer) beneath the referenced dotted configuration
+// section, so the section is deserialized into the target with problem(s) reported by their full key.
+func nest(target any, section string) any {
+	v := reflect.ValueOf(target)
+	parts := strings.Split(section, ".")
+	for i := len(parts) - 1; i >= 0; i-- {
+		w := reflect.New(reflect.StructOf([]reflect.StructField{{
+			Name: "Section",
+			Type: v.Type(),
+			// the section is always present; struct tag default(s) of the target apply beneath it
+			Tag: reflect.StructTag(fmt.Sprintf(`mapstructure:%q validate:"required"`, strings.ToLower(parts[i]))),
+		}}))
+		w.Elem().Field(0).Set(v)
+		v = w
+	}
+	return v.Interface()
+}
+
+// newConfig constructs a new, empty instance of the configuration type returned by the service; used so candidate
+// configuration(s) can be loaded without mutating the live configuration of the service.
+func newConfig(service Service) (any, error) {
+	t := reflect.TypeOf(service.Config())
+	if t == nil || t.Kind() != reflect.Ptr {
+		return nil, fmt.Errorf("service configuration must be a pointer; got %T", service.Config())
+	}
+	return reflect.New(t.Elem()).Interface(), nil
+}
+
+// environments returns the sorted environment(s) with a configuration file within the embedded file system or search
+// path(s) of the loader; the default and local override file(s) are layers rather than environment(s) and are excluded.
+func (l *loader) environments() ([]string, error) {
+	seen := map[string]bool{}
+
+	collect := func(entries []fs.DirEntry) {
+		for _, e := range entries {
+			ext := strings.TrimPrefix(filepath.Ext(e.Name()), ".")
+			if e.IsDir() || !supportedExt(ext) {
+				continue
+			}
+			name := strings.TrimSuffix(e.Name(), "."+ext)
+			if name == defaultConfigName || strings.HasSuffix(name, localConfigSuffix) {
+				continue
+			}
+			seen[name] = true
+		}
+	}
+
+	if l.options.fsys != nil {
+		entries, err := fs.ReadDir(l.options.fsys, ".")
+		if err != nil {
+			return nil, err
+		}
+		collect(entries)
+	}
+	for _, p := range l.paths {
+		entries, err := os.ReadDir(p)
+		if err != nil {
+			// search path(s) are optional; e.g. /etc/<service> only exists once deployed
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			return nil, err
+		}
+		collect(entries)
+	}
+
+	envs := make([]string, 0, len(seen))
+	for env := range seen {
+		envs = append(envs, env)
+	}
+	sort.Strings(envs)
+	return envs, nil
+}
+
+// supportedExt reports whether the referenced file extension is a configuration format supported by viper.
+func supportedExt(ext string) bool {
+	for _, e := range viper.SupportedExts {
+		if e == ext {
+			return true
+		}
+	}
+	return false
+}
+
+// flagKey returns the configuration key in which the referenced flag of a service is bound.
+func flagKey(service, flag string) string {
+	return strings.ToLower(fmt.Sprintf("%s.%s", service, flag))
+}
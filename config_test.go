@@ -0,0 +1,255 @@
+package saddle
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+type (
+	// testConfig contains the service configuration loaded by test(s).
+	testConfig struct {
+		Name string `mapstructure:"name"`
+	}
+)
+
+// testLoad loads the referenced environment from the referenced (embedded) configuration file(s) into the referenced
+// target; the configuration error is returned when the configuration is invalid.
+func testLoad(t *testing.T, files map[string]string, environment string, target any) (*loaded, *ConfigError) {
+	t.Helper()
+
+	fsys := fstest.MapFS{}
+	for name, data := range files {
+		fsys[name] = &fstest.MapFile{Data: []byte(data)}
+	}
+
+	o := newOptions(WithFS(fsys), WithSearchPaths(t.TempDir()))
+	l := &loader{
+		environment: environment,
+		options:     o,
+		paths:       o.searchPaths,
+		service:     "svc",
+	}
+
+	ld, err := l.load(target)
+	if err == nil {
+		return ld, nil
+	}
+	var ce *ConfigError
+	if !errors.As(err, &ce) {
+		t.Fatalf("load returned %T; want *ConfigError: %v", err, err)
+	}
+	return nil, ce
+}
+
+// problemKeys returns the key and tag of each problem of the referenced configuration error.
+func problemKeys(ce *ConfigError) map[string]string {
+	keys := map[string]string{}
+	if ce != nil {
+		for _, p := range ce.Problems {
+			keys[p.Key] = p.Tag
+		}
+	}
+	return keys
+}
+
+func TestLoadEnvironmentVariables(t *testing.T) {
+	tests := []struct {
+		name    string
+		config  string
+		env     map[string]string
+		key     string
+		want    string
+		source  SourceKind
+		secrets map[string]string
+	}{
+		{
+			name:   "key set by no other source",
+			config: `saddle: {}`,
+			env:    map[string]string{"SADDLE_SERVER_PROXY_HEADER": "X-Forwarded-For"},
+			key:    "saddle.server.proxy_header",
+			want:   "X-Forwarded-For",
+			source: SourceEnvVar,
+		},
+		{
+			name:   "key set by file",
+			config: `saddle: {server: {proxy_header: X-Real-IP}}`,
+			env:    map[string]string{"SADDLE_SERVER_PROXY_HEADER": "X-Forwarded-For"},
+			key:    "saddle.server.proxy_header",
+			want:   "X-Forwarded-For",
+			source: SourceEnvVar,
+		},
+		{
+			name:   "key of optional block",
+			config: `saddle: {otlp: {sample_rate: 100}}`,
+			env:    map[string]string{"SADDLE_OTLP_ENDPOINT": "http://localhost:4318"},
+			key:    "saddle.otlp.endpoint",
+			want:   "http://localhost:4318",
+			source: SourceEnvVar,
+		},
+		{
+			// regression: binding saddle.otlp.headers hid its entries from secret resolution
+			name: "secret reference within map",
+			config: `saddle:
+  otlp:
+    endpoint: http://localhost:4318
+    sample_rate: 100
+    headers: {authorization: "base64://aHVudGVyMg=="}`,
+			key:     "saddle.otlp.headers.authorization",
+			want:    "hunter2",
+			source:  SourceEmbeddedFile,
+			secrets: map[string]string{"saddle.otlp.headers.authorization": "hunter2"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			ld, ce := testLoad(t, map[string]string{"test.yaml": tt.config}, "test", &testConfig{})
+			if ce != nil {
+				t.Fatalf("load: %v", ce)
+			}
+
+			if got := ld.viper.GetString(tt.key); got != tt.want {
+				t.Errorf("%s = %q; want %q", tt.key, got, tt.want)
+			}
+			if s, _ := ld.provenance.Lookup(tt.key); s.Kind != tt.source {
+				t.Errorf("source of %s = %q; want %q", tt.key, s.Kind, tt.source)
+			}
+			for k, v := range tt.secrets {
+				if ld.secrets[k] != v {
+					t.Errorf("secret %s = %q; want %q", k, ld.secrets[k], v)
+				}
+			}
+		})
+	}
+}
+
+func TestLoadOTLPHeaderSecrets(t *testing.T) {
+	ld, ce := testLoad(t, map[string]string{"test.yaml": `saddle:
+  otlp:
+    endpoint: http://localhost:4318
+    sample_rate: 100
+    headers: {authorization: "base64://aHVudGVyMg=="}`}, "test", &testConfig{})
+	if ce != nil {
+		t.Fatalf("load: %v", ce)
+	}
+
+	// the exporter must receive the resolved secret rather than the reference
+	if got := ld.config.Saddle.OTLP.Headers["authorization"]; got != "hunter2" {
+		t.Errorf("authorization header = %q; want %q", got, "hunter2")
+	}
+}
+
+func TestLoadSection(t *testing.T) {
+	type sectionConfig struct {
+		Name string `mapstructure:"name" validate:"required"`
+		Port int    `mapstructure:"port" default:"8080"`
+	}
+
+	tests := []struct {
+		name         string
+		section      string
+		config       string
+		env          map[string]string
+		want         sectionConfig
+		wantDrain    time.Duration
+		wantSource   SourceKind
+		wantProblems map[string]string
+	}{
+		{
+			name:       "root",
+			config:     `{name: root, saddle: {shutdown: {drain_timeout: 5s}}, public: {name: public}}`,
+			want:       sectionConfig{Name: "root", Port: 8080},
+			wantDrain:  5 * time.Second,
+			wantSource: SourceEmbeddedFile,
+		},
+		{
+			// the shared saddle configuration applies unless the section overrides it
+			name:       "section",
+			section:    "public",
+			config:     `{name: root, saddle: {shutdown: {drain_timeout: 5s}}, public: {name: public}}`,
+			want:       sectionConfig{Name: "public", Port: 8080},
+			wantDrain:  5 * time.Second,
+			wantSource: SourceEmbeddedFile,
+		},
+		{
+			name:    "section overrides saddle",
+			section: "public",
+			config: `
+saddle: {shutdown: {drain_timeout: 5s}}
+public: {name: public, port: 9090, saddle: {shutdown: {drain_timeout: 1s}}}`,
+			want:       sectionConfig{Name: "public", Port: 9090},
+			wantDrain:  time.Second,
+			wantSource: SourceEmbeddedFile,
+		},
+		{
+			name:       "nested section",
+			section:    "members.public",
+			config:     `{members: {public: {name: public}}}`,
+			env:        map[string]string{"MEMBERS_PUBLIC_PORT": "9191"},
+			want:       sectionConfig{Name: "public", Port: 9191},
+			wantDrain:  10 * time.Second,
+			wantSource: SourceStructDefault,
+		},
+		{
+			name:    "problems are reported by their full key",
+			section: "public",
+			config:  `{name: root, public: {saddle: {shutdown: {drain_timeout: never}}}}`,
+			wantProblems: map[string]string{
+				"public.saddle.shutdown.drain_timeout": "",
+				"public.name":                          "required",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+			o := newOptions(
+				WithFS(fstest.MapFS{"test.yaml": &fstest.MapFile{Data: []byte(tt.config)}}),
+				WithSearchPaths(t.TempDir()),
+				WithConfigSection(tt.section),
+			)
+			l := &loader{
+				environment: "test",
+				options:     o,
+				paths:       o.searchPaths,
+				service:     "svc",
+			}
+
+			cfg := &sectionConfig{}
+			ld, err := l.load(cfg)
+			if tt.wantProblems != nil {
+				var ce *ConfigError
+				if !errors.As(err, &ce) {
+					t.Fatalf("load error = %v; want *ConfigError", err)
+				}
+				if got := problemKeys(ce); !reflect.DeepEqual(got, tt.wantProblems) {
+					t.Errorf("problems = %v; want %v", got, tt.wantProblems)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+
+			if *cfg != tt.want {
+				t.Errorf("config = %+v; want %+v", *cfg, tt.want)
+			}
+			if got := ld.config.Saddle.Shutdown.DrainTimeout; got != tt.wantDrain {
+				t.Errorf("drain timeout = %s; want %s", got, tt.wantDrain)
+			}
+			if s, _ := ld.provenance.Lookup("saddle.shutdown.drain_timeout"); s.Kind != tt.wantSource {
+				t.Errorf("source of saddle.shutdown.drain_timeout = %s; want %s", s, tt.wantSource)
+			}
+		})
+	}
+}
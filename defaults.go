@@ -0,0 +1,129 @@
+package saddle
+
+import (
+	"reflect"
+	"strings"
+)
+
+// defaultTag contains the struct tag referencing the default value of a configuration field (e.g. `default:"gzip"`).
+const defaultTag = "default"
+
+// structDefaults returns the struct tag default(s) of the referenced configuration type keyed by configuration key.
+// The default(s) of a nested pointer struct are only returned when the struct is required (validate:"required") or
+// present reports it as set by another source; otherwise defaulting a single field would conjure an optional block
+// (e.g. an exporter) which was never configured.
+func structDefaults(t reflect.Type, prefix string, present func(key string) bool) map[string]string {
+	defaults := map[string]string{}
+
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return defaults
+	}
+
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Tag.Get("mapstructure") == "-" {
+			continue
+		}
+
+		key := prefix
+		if !strings.Contains(f.Tag.Get("mapstructure"), ",squash") {
+			key = joinKey(prefix, mapstructureName(f))
+		}
+		if d, ok := f.Tag.Lookup(defaultTag); ok {
+			defaults[key] = d
+			continue
+		}
+
+		ft := f.Type
+		if ft.Kind() == reflect.Ptr {
+			if ft.Elem().Kind() != reflect.Struct || !(required(f) || present(key)) {
+				continue
+			}
+			ft = ft.Elem()
+		}
+		if ft.Kind() == reflect.Struct {
+			for k, d := range structDefaults(ft, key, present) {
+				defaults[k] = d
+			}
+		}
+	}
+	return defaults
+}
+
+// structKeys returns the configuration key of every leaf field of the referenced configuration type, including those
+// of optional (pointer) struct(s); used to bind environment variable(s) to keys no other source sets. Map field(s) are
+// excluded: a bound key is reported by viper as a leaf, which would hide the entries of the map (e.g. secret
+// reference(s) within saddle.otlp.headers) from every other source.
+func structKeys(t reflect.Type, prefix string) []string {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct || t == durationType {
+		return nil
+	}
+
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Tag.Get("mapstructure") == "-" {
+			continue
+		}
+
+		key := prefix
+		if !strings.Contains(f.Tag.Get("mapstructure"), ",squash") {
+			key = joinKey(prefix, mapstructureName(f))
+		}
+		ft := f.Type
+		for ft.Kind() == reflect.Ptr {
+			ft = ft.Elem()
+		}
+		switch {
+		case ft.Kind() == reflect.Map:
+			continue
+		case ft.Kind() == reflect.Struct && ft != durationType:
+			keys = append(keys, structKeys(ft, key)...)
+			continue
+		}
+		keys = append(keys, key)
+	}
+	return keys
+}
+
+// required reports whether the referenced field is tagged as required.
+func required(f reflect.StructField) bool {
+	for _, rule := range strings.Split(f.Tag.Get("validate"), ",") {
+		if rule == "required" {
+			return true
+		}
+	}
+	return false
+}
+
+// defaulted reports whether the value of the referenced field is supplied by struct tag default(s) when no other source
+// sets it; a struct is defaulted when it holds at least one default (so the block is conjured) and every required
+// field within it is defaulted.
+func defaulted(f reflect.StructField) bool {
+	if _, ok := f.Tag.Lookup(defaultTag); ok {
+		return true
+	}
+
+	t := f.Type
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct || t == durationType {
+		return false
+	}
+	if len(structDefaults(t, "", func(string) bool { return false })) == 0 {
+		return false
+	}
+	for i := 0; i < t.NumField(); i++ {
+		if sf := t.Field(i); sf.IsExported() && required(sf) && !defaulted(sf) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,137 @@
+package saddle
+
+import (
+	"reflect"
+	"testing"
+)
+
+type (
+	// defaultsConfig contains the configuration reflected over by default(s) test(s).
+	defaultsConfig struct {
+		Name     string          `mapstructure:"name" default:"svc"`
+		Plain    string          `mapstructure:"plain"`
+		Server   defaultsServer  `mapstructure:"server"`
+		Required *defaultsServer `mapstructure:"required" validate:"required"`
+		Optional *defaultsServer `mapstructure:"optional"`
+		Squashed defaultsServer  `mapstructure:",squash"`
+	}
+
+	// defaultsServer contains a nested block of defaultsConfig.
+	defaultsServer struct {
+		Address string `mapstructure:"address" validate:"required" default:":8080"`
+		TLS     bool   `mapstructure:"tls"`
+	}
+)
+
+func TestStructDefaults(t *testing.T) {
+	tests := []struct {
+		name    string
+		present []string
+		want    map[string]string
+	}{
+		{
+			name: "optional block absent",
+			want: map[string]string{
+				"name":             "svc",
+				"server.address":   ":8080",
+				"required.address": ":8080",
+				"address":          ":8080",
+			},
+		},
+		{
+			name:    "optional block set by another source",
+			present: []string{"optional"},
+			want: map[string]string{
+				"name":             "svc",
+				"server.address":   ":8080",
+				"required.address": ":8080",
+				"optional.address": ":8080",
+				"address":          ":8080",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			present := func(key string) bool {
+				for _, k := range tt.present {
+					if k == key {
+						return true
+					}
+				}
+				return false
+			}
+			if got := structDefaults(reflect.TypeOf(&defaultsConfig{}), "", present); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("defaults = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLoadDefaults(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   string
+	}{
+		{name: "unset", config: `plain: value`, want: "svc"},
+		{name: "overridden", config: `name: override`, want: "override"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := &defaultsConfig{}
+			ld, ce := testLoad(t, map[string]string{"test.yaml": tt.config}, "test", config)
+			if ce != nil {
+				t.Fatalf("load: %v", ce)
+			}
+			if config.Name != tt.want {
+				t.Errorf("name = %q; want %q", config.Name, tt.want)
+			}
+			if config.Required == nil || config.Required.Address != ":8080" {
+				t.Errorf("required block = %+v; want defaulted address", config.Required)
+			}
+			if config.Optional != nil {
+				t.Errorf("optional block = %+v; want nil", config.Optional)
+			}
+			// saddle default(s) apply alongside the service default(s)
+			if ld.config.Saddle.Lifecycle.StopTimeout == 0 {
+				t.Error("saddle.lifecycle.stop_timeout was not defaulted")
+			}
+		})
+	}
+}
+
+func TestDefaulted(t *testing.T) {
+	type (
+		empty struct {
+			URI string `mapstructure:"uri"`
+		}
+		partial struct {
+			Level string `mapstructure:"level" validate:"required" default:"info"`
+			URI   string `mapstructure:"uri" validate:"required"`
+		}
+		config struct {
+			Tagged  string          `default:"svc"`
+			Plain   string          `validate:"required"`
+			Block   defaultsServer  `validate:"required"`
+			Pointer *defaultsServer `validate:"required"`
+			Empty   *empty          `validate:"required"`
+			Partial *partial        `validate:"required"`
+			Count   int64           `validate:"required"`
+		}
+	)
+
+	want := map[string]bool{
+		"Tagged":  true,
+		"Block":   true,
+		"Pointer": true,
+	}
+	ct := reflect.TypeOf(config{})
+	for i := 0; i < ct.NumField(); i++ {
+		f := ct.Field(i)
+		if got := defaulted(f); got != want[f.Name] {
+			t.Errorf("defaulted(%s) = %t; want %t", f.Name, got, want[f.Name])
+		}
+	}
+}
@@ -0,0 +1,142 @@
+package saddle
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/mitchellh/mapstructure"
+)
+
+const (
+	// exitFailure contains the exit code of a service which failed.
+	exitFailure = 1
+	// exitConfig contains the exit code of a service with an invalid configuration (EX_CONFIG of sysexits.h).
+	exitConfig = 78
+)
+
+type (
+	// ConfigError contains the problem(s) encountered while loading the configuration of a service.
+	ConfigError struct {
+		// Path contains the path of the loaded configuration file; the search path(s) when no file was found.
+		Path string
+		// Environment contains the environment of the loaded configuration.
+		Environment string
+		// Problems contains the field-level problem(s) of the loaded configuration.
+		Problems []*FieldProblem
+		// Err contains the underlying error of a configuration file which could not be read, if any.
+		Err error
+	}
+
+	// FieldProblem contains a problem pertaining to a single configuration field.
+	FieldProblem struct {
+		// Key contains the dotted mapstructure key of the field (e.g. saddle.jaeger.uri).
+		Key string
+		// Tag contains the failing validation tag of the field (e.g. required); empty when the field failed to decode.
+		Tag string
+		// Param contains the parameter of the failing validation tag, if any.
+		Param string
+		// Message contains a user-friendly message pertaining to the details of the problem.
+		Message string
+	}
+)
+
+// Error returns a readable multi-line report of the configuration problem(s).
+func (e *ConfigError) Error() string {
+	var b strings.Builder
+
+	fmt.Fprintf(&b, "invalid configuration [environment: %s, path: %s]", e.Environment, e.Path)
+	if e.Err != nil {
+		fmt.Fprintf(&b, ": %s", e.Err)
+	}
+	for _, p := range e.Problems {
+		if p.Key == "" {
+			fmt.Fprintf(&b, "\n  - %s", p.Message)
+			continue
+		}
+		fmt.Fprintf(&b, "\n  - %s: %s", p.Key, p.Message)
+	}
+	return b.String()
+}
+
+// Unwrap returns the underlying error of the configuration error, if any.
+func (e *ConfigError) Unwrap() error {
+	return e.Err
+}
+
+// appendProblems appends the field-level problem(s) referenced by a deserialization or validation error.
+func (e *ConfigError) appendProblems(err error) {
+	var (
+		de *mapstructure.Error
+		ve validator.ValidationErrors
+	)
+
+	switch {
+	case errors.As(err, &ve):
+		for _, fe := range ve {
+			p := &FieldProblem{
+				Key:   fieldKey(fe.Namespace()),
+				Tag:   fe.Tag(),
+				Param: fe.Param(),
+			}
+			p.Message = fmt.Sprintf("failed %q validation", p.Tag)
+			if p.Param != "" {
+				p.Message = fmt.Sprintf("failed %q validation [%s]", p.Tag, p.Param)
+			}
+			e.Problems = append(e.Problems, p)
+		}
+	case errors.As(err, &de):
+		for _, m := range de.Errors {
+			e.Problems = append(e.Problems, decodeProblem(m))
+		}
+	default:
+		e.Problems = append(e.Problems, &FieldProblem{Message: err.Error()})
+	}
+}
+
+// decodeProblem constructs a field-level problem from a mapstructure decoding message; the key is the first quoted
+// segment of the message (e.g. "cannot parse 'saddle.stdout.sample_rate' as float").
+func decodeProblem(message string) *FieldProblem {
+	if _, rest, ok := strings.Cut(message, "'"); ok {
+		if key, _, ok := strings.Cut(rest, "'"); ok {
+			return &FieldProblem{
+				Key:     key,
+				Message: message,
+			}
+		}
+	}
+	return &FieldProblem{Message: message}
+}
+
+// fieldKey returns the dotted mapstructure key of a validation namespace by trimming the root struct name.
+func fieldKey(namespace string) string {
+	if _, key, ok := strings.Cut(namespace, "."); ok {
+		return key
+	}
+	return namespace
+}
+
+// mapstructureName returns the mapstructure name of a struct field; used so validation namespaces match the keys of the
+// configuration file(s).
+func mapstructureName(field reflect.StructField) string {
+	name, _, _ := strings.Cut(field.Tag.Get("mapstructure"), ",")
+	if name == "" {
+		return strings.ToLower(field.Name)
+	}
+	return name
+}
+
+// ExitCode returns the process exit code of the error returned by running a service (e.g. by the entry returned by
+// Instantiate): 0 when nil, 78 (EX_CONFIG) when the configuration is invalid and 1 otherwise.
+func ExitCode(err error) int {
+	var ce *ConfigError
+	switch {
+	case err == nil:
+		return 0
+	case errors.As(err, &ce):
+		return exitConfig
+	}
+	return exitFailure
+}
@@ -0,0 +1,132 @@
+package saddle
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestLoadConfigError(t *testing.T) {
+	tests := []struct {
+		name     string
+		files    map[string]string
+		wantErr  bool
+		wantPath string
+		want     map[string]string
+	}{
+		{
+			name:  "valid",
+			files: map[string]string{"test.yaml": `name: svc`},
+			want:  map[string]string{},
+		},
+		{
+			name:    "missing environment file",
+			files:   map[string]string{"default.yaml": `name: svc`},
+			wantErr: true,
+			want:    map[string]string{},
+		},
+		{
+			name:     "malformed file",
+			files:    map[string]string{"test.yaml": "name: [svc"},
+			wantErr:  true,
+			wantPath: "embed:test.yaml",
+			want:     map[string]string{},
+		},
+		{
+			name:     "undecodable value",
+			files:    map[string]string{"test.yaml": `saddle: {stdout: {sample_rate: fast}}`},
+			wantPath: "embed:test.yaml",
+			want:     map[string]string{"saddle.stdout.sample_rate": ""},
+		},
+		{
+			name:     "invalid value",
+			files:    map[string]string{"test.yaml": `saddle: {stdout: {sample_rate: 101}}`},
+			wantPath: "embed:test.yaml",
+			want:     map[string]string{"saddle.stdout.sample_rate": "max"},
+		},
+		{
+			name:     "missing required value",
+			files:    map[string]string{"test.yaml": `saddle: {jaeger: {sample_rate: 100}}`},
+			wantPath: "embed:test.yaml",
+			want:     map[string]string{"saddle.jaeger.uri": "required"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ce := testLoad(t, tt.files, "test", &testConfig{})
+
+			if ce != nil && tt.wantPath != "" && ce.Path != tt.wantPath {
+				t.Errorf("path = %q; want %q", ce.Path, tt.wantPath)
+			}
+			if gotErr := ce != nil && ce.Err != nil; gotErr != tt.wantErr {
+				t.Errorf("underlying error = %v; want error %t", ce.Err, tt.wantErr)
+			}
+			if got := problemKeys(ce); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("problems = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigErrorError(t *testing.T) {
+	ce := &ConfigError{
+		Path:        ".config/test.yaml",
+		Environment: "test",
+		Problems: []*FieldProblem{
+			{Key: "saddle.jaeger.uri", Message: `failed "required" validation`},
+			{Message: "unreadable"},
+		},
+	}
+
+	want := strings.Join([]string{
+		"invalid configuration [environment: test, path: .config/test.yaml]",
+		`  - saddle.jaeger.uri: failed "required" validation`,
+		"  - unreadable",
+	}, "\n")
+	if got := ce.Error(); got != want {
+		t.Errorf("Error() = %q; want %q", got, want)
+	}
+}
+
+func TestExitCode(t *testing.T) {
+	tests := []struct {
+		name string
+		err  error
+		want int
+	}{
+		{name: "success", want: 0},
+		{name: "failure", err: errors.New("failed"), want: exitFailure},
+		{name: "configuration", err: &ConfigError{}, want: exitConfig},
+		{name: "wrapped configuration", err: fmt.Errorf("svc: %w", &ConfigError{}), want: exitConfig},
+		{name: "joined configuration", err: errors.Join(errors.New("failed"), &ConfigError{}), want: exitConfig},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := ExitCode(tt.err); got != tt.want {
+				t.Errorf("ExitCode() = %d; want %d", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestDecodeProblem(t *testing.T) {
+	tests := []struct {
+		message string
+		want    string
+	}{
+		{message: "cannot parse 'saddle.stdout.sample_rate' as float", want: "saddle.stdout.sample_rate"},
+		{message: "unquoted problem", want: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.message, func(t *testing.T) {
+			if got := decodeProblem(tt.message).Key; got != tt.want {
+				t.Errorf("key = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
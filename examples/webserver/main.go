@@ -1,15 +1,17 @@
 package main
 
 import (
+	"embed"
 	"fmt"
+	"io/fs"
 	"os"
+	"sync"
 
 	"github.com/captjt/saddle"
 	"github.com/captjt/saddle/pkg/logger"
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 	"go.uber.org/zap"
 
 	"saddle/examples/webserver/models"
@@ -26,7 +28,9 @@ type (
 		description string
 		app         *fiber.App
 		logger      *logger.Logger
+		mu          sync.RWMutex
 		name        string
+		project     *saddle.Project[*Service]
 		validator   *validator.Validate
 		extended
 	}
@@ -44,6 +48,9 @@ const (
 var (
 	_cleanup cleanup
 
+	//go:embed defaults
+	defaults embed.FS
+
 	command *cobra.Command
 	version string
 )
@@ -51,27 +58,22 @@ var (
 func init() {
 
 	command = saddle.New(version)
-	service := New(description, name, validator.New())
-	webserver := saddle.Command(saddle.Instantiate(service))
+	embedded, _ := fs.Sub(defaults, "defaults")
+	service, entry := saddle.Instantiate(New(description, name, validator.New()),
+		saddle.WithEnvPrefix(name),
+		saddle.WithFS(embedded),
+		saddle.WithSearchPaths("/etc/"+name, "$HOME/.config/"+name, ".config"),
+	)
+	webserver := saddle.Command(service, entry,
+		saddle.WithFlag("test", "v1.test", "test configuration passed through to the v1 handlers"),
+	)
 	command.AddCommand(webserver)
-
-	// - define command-line parameters ↴
-	webserver.PersistentFlags().StringP("environment", "e", "", "environment of service deployment")
-	webserver.PersistentFlags().StringP("address", "a", "", "address | interface to listen for incoming requests")
-	webserver.PersistentFlags().Set("environment", os.Getenv("ENVIRONMENT"))
-	webserver.PersistentFlags().Set("address", os.Getenv("ADDRESS"))
-
-	// - mark properties as required ↴
-	webserver.MarkPersistentFlagRequired("environment")
-	webserver.MarkPersistentFlagRequired("address")
-
-	// - bind persistent command-line flags to viper so they are accessible within the service ↴
-	_ = viper.BindPFlag(fmt.Sprintf("%s.%s", name, "environment"), webserver.PersistentFlags().Lookup("environment"))
-	_ = viper.BindPFlag(fmt.Sprintf("%s.%s", name, "address"), webserver.PersistentFlags().Lookup("address"))
 }
 
 func main() {
-	command.Execute()
+	if err := command.Execute(); err != nil {
+		os.Exit(saddle.ExitCode(err))
+	}
 }
 
 func New(description, name string, validator *validator.Validate) *Service {
@@ -83,6 +85,10 @@ func New(description, name string, validator *validator.Validate) *Service {
 	}
 }
 
+func (s *Service) Bind(project *saddle.Project[*Service]) {
+	s.project = project
+}
+
 func (s *Service) Attach(e *fiber.App, logger *logger.Logger, validator *validator.Validate) (
 	func(), error,
 ) {
@@ -100,6 +106,12 @@ func (s *Service) construct() error {
 
 	// Purely to show passing configurations through to handlers.
 	s.extended.test = s.config.V1.Test
+	if source, ok := s.project.Provenance().Lookup("v1.test"); ok {
+		s.logger.Info("webserver service configuration loaded",
+			zap.String("key", "v1.test"),
+			zap.Stringer("source", source),
+		)
+	}
 
 	// Instantiate and load v1 module: handlers, middleware, etc.
 	if err := v1.New(
@@ -117,6 +129,9 @@ func (s *Service) construct() error {
 }
 
 func (s *Service) Config() any {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
 	return s.config
 }
 
@@ -132,6 +147,22 @@ func (s *Service) Validator() *validator.Validate {
 	return s.validator
 }
 
+func (s *Service) Reload(config any) error {
+	c, ok := config.(*models.Config)
+	if !ok {
+		return fmt.Errorf("unexpected configuration type: %T", config)
+	}
+
+	s.mu.Lock()
+	s.config = c
+	s.mu.Unlock()
+
+	s.logger.Info("webserver service configuration reloaded",
+		zap.String("test", c.V1.Test),
+	)
+	return nil
+}
+
 func (s *Service) shutdown() {
 	for _, f := range _cleanup {
 		f()
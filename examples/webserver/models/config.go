@@ -7,6 +7,6 @@ type (
 
 	V1 struct {
 		// Specific V1 handler configurations can go in here.
-		Test string `mapstructure:"test" validate:"required"`
+		Test string `mapstructure:"test" validate:"required" default:"configurations can be put here"`
 	}
 )
@@ -1,6 +1,7 @@
 package handlers
 
 import (
+	"github.com/captjt/saddle/pkg/logger"
 	"github.com/gofiber/fiber/v2"
 	"go.uber.org/zap"
 
@@ -11,7 +12,7 @@ func (h *Handlers) helloWorld() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		in := c.Locals("request").(*models.HelloWorldRequest)
 
-		h.logger.Info("retrieving application embedding",
+		logger.FromContext(c.UserContext()).Info("retrieving application embedding",
 			zap.String("message", in.Message),
 		)
 
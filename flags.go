@@ -0,0 +1,129 @@
+package saddle
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/spf13/pflag"
+)
+
+// configKeyAnnotation contains the pflag annotation referencing the configuration key in which a service flag is bound.
+const configKeyAnnotation = "saddle_config_key"
+
+type (
+	// CommandOption configures the command constructed for a service.
+	CommandOption func(*commandOptions)
+
+	commandOptions struct {
+		flags []*serviceFlag
+	}
+
+	// serviceFlag contains a service specific flag bound to a key of the service configuration.
+	serviceFlag struct {
+		key       string
+		name      string
+		shorthand string
+		usage     string
+	}
+)
+
+// WithFlag defines a service specific flag bound to the referenced (mapstructure) key of the service configuration
+// (e.g. WithFlag("test", "v1.test", "...") binds --test to v1.test); the type of the flag is derived from the field.
+func WithFlag(name, key, usage string) CommandOption {
+	return WithFlagP(name, "", key, usage)
+}
+
+// WithFlagP is like WithFlag, but accepts a shorthand letter that can be used after a single dash.
+func WithFlagP(name, shorthand, key, usage string) CommandOption {
+	return func(o *commandOptions) {
+		o.flags = append(o.flags, &serviceFlag{
+			key:       strings.ToLower(key),
+			name:      name,
+			shorthand: shorthand,
+			usage:     usage,
+		})
+	}
+}
+
+// newCommandOptions constructs the command options of a service from the referenced option(s).
+func newCommandOptions(opts ...CommandOption) *commandOptions {
+	o := &commandOptions{}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
+
+// defineFlag defines the referenced service flag within the flag set, typed according to the field of the
+// configuration it is bound to, and annotates the flag with its configuration key.
+func defineFlag(fs *pflag.FlagSet, config any, sf *serviceFlag) error {
+	t, ok := keyType(reflect.TypeOf(config), sf.key)
+	if !ok {
+		return fmt.Errorf("configuration key %s not found within %T", sf.key, config)
+	}
+
+	switch {
+	case t == durationType:
+		fs.DurationP(sf.name, sf.shorthand, 0, sf.usage)
+	case t.Kind() == reflect.Bool:
+		fs.BoolP(sf.name, sf.shorthand, false, sf.usage)
+	case t.Kind() >= reflect.Int && t.Kind() <= reflect.Int64:
+		fs.Int64P(sf.name, sf.shorthand, 0, sf.usage)
+	case t.Kind() >= reflect.Uint && t.Kind() <= reflect.Uint64:
+		fs.Uint64P(sf.name, sf.shorthand, 0, sf.usage)
+	case t.Kind() == reflect.Float32 || t.Kind() == reflect.Float64:
+		fs.Float64P(sf.name, sf.shorthand, 0, sf.usage)
+	case t.Kind() == reflect.Slice || t.Kind() == reflect.Array:
+		// element(s) are weakly decoded into the field type on unmarshal
+		fs.StringSliceP(sf.name, sf.shorthand, nil, sf.usage)
+	case t.Kind() == reflect.String:
+		fs.StringP(sf.name, sf.shorthand, "", sf.usage)
+	default:
+		return fmt.Errorf("configuration key %s has unsupported flag type %s", sf.key, t)
+	}
+	return fs.SetAnnotation(sf.name, configKeyAnnotation, []string{sf.key})
+}
+
+// keyType returns the type of the field referenced by the dotted (mapstructure) key within the referenced type.
+func keyType(t reflect.Type, key string) (reflect.Type, bool) {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil {
+		return nil, false
+	}
+	if key == "" {
+		return t, true
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, false
+	}
+
+	head, rest, _ := strings.Cut(key, ".")
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Tag.Get("mapstructure") == "-" {
+			continue
+		}
+		if strings.Contains(f.Tag.Get("mapstructure"), ",squash") {
+			if ft, ok := keyType(f.Type, key); ok {
+				return ft, true
+			}
+			continue
+		}
+		if strings.EqualFold(mapstructureName(f), head) {
+			return keyType(f.Type, rest)
+		}
+	}
+	return nil, false
+}
+
+// boundKey returns the configuration key in which the referenced flag of a service is bound; service specific flags
+// are bound to their annotated configuration key, any other flag beneath the service name.
+func boundKey(service string, f *pflag.Flag) string {
+	if keys := f.Annotations[configKeyAnnotation]; len(keys) > 0 {
+		return keys[0]
+	}
+	return flagKey(service, f.Name)
+}
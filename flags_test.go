@@ -0,0 +1,142 @@
+package saddle
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/spf13/pflag"
+)
+
+type (
+	// flagConfig contains the service configuration service specific flag(s) are bound to by test(s).
+	flagConfig struct {
+		Timeout time.Duration     `mapstructure:"timeout"`
+		Workers int               `mapstructure:"workers"`
+		Verbose bool              `mapstructure:"verbose"`
+		Hosts   []string          `mapstructure:"hosts"`
+		Ratio   float64           `mapstructure:"ratio"`
+		Labels  map[string]string `mapstructure:"labels"`
+		Region  flagRegion        `mapstructure:",squash"`
+		Nested  *flagNested       `mapstructure:"nested"`
+	}
+
+	// flagRegion contains configuration squashed into flagConfig.
+	flagRegion struct {
+		Region string `mapstructure:"region"`
+	}
+
+	// flagNested contains configuration referenced by pointer from flagConfig.
+	flagNested struct {
+		Limit uint `mapstructure:"limit"`
+	}
+)
+
+func TestDefineFlag(t *testing.T) {
+	tests := []struct {
+		name     string
+		key      string
+		wantType string
+		wantErr  string
+	}{
+		{name: "duration", key: "timeout", wantType: "duration"},
+		{name: "int", key: "workers", wantType: "int64"},
+		{name: "bool", key: "verbose", wantType: "bool"},
+		{name: "slice", key: "hosts", wantType: "stringSlice"},
+		{name: "float", key: "ratio", wantType: "float64"},
+		{name: "squashed struct", key: "region", wantType: "string"},
+		{name: "pointer struct", key: "nested.limit", wantType: "uint64"},
+		{name: "keys are case insensitive", key: "Nested.Limit", wantType: "uint64"},
+		{name: "unknown key", key: "nested.missing", wantErr: "configuration key nested.missing not found"},
+		{name: "unsupported type", key: "labels", wantErr: "unsupported flag type map[string]string"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fs := pflag.NewFlagSet("svc", pflag.ContinueOnError)
+			var o commandOptions
+			WithFlag("flag", tt.key, "usage")(&o)
+
+			err := defineFlag(fs, &flagConfig{}, o.flags[0])
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v; want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("defineFlag: %v", err)
+			}
+
+			f := fs.Lookup("flag")
+			if got := f.Value.Type(); got != tt.wantType {
+				t.Errorf("type = %q; want %q", got, tt.wantType)
+			}
+			// the flag is bound to the (lower-cased) configuration key rather than beneath the service name
+			if got, want := boundKey("svc", f), strings.ToLower(tt.key); got != want {
+				t.Errorf("bound key = %q; want %q", got, want)
+			}
+		})
+	}
+}
+
+func TestServiceFlagOverridesFile(t *testing.T) {
+	o := newOptions(
+		WithFS(fstest.MapFS{"test.yaml": &fstest.MapFile{Data: []byte(`
+timeout: 1s
+workers: 1
+hosts: [a]
+region: file
+nested: {limit: 1}`)}}),
+		WithSearchPaths(t.TempDir()),
+	)
+
+	fs := pflag.NewFlagSet("svc", pflag.ContinueOnError)
+	for _, key := range []string{"timeout", "workers", "hosts", "region", "nested.limit"} {
+		sf := &serviceFlag{key: key, name: strings.ReplaceAll(key, ".", "-")}
+		if err := defineFlag(fs, &flagConfig{}, sf); err != nil {
+			t.Fatal(err)
+		}
+	}
+	args := []string{"--timeout", "2s", "--workers", "3", "--hosts", "b,c", "--region", "flag", "--nested-limit", "5"}
+	if err := fs.Parse(args); err != nil {
+		t.Fatal(err)
+	}
+
+	l := &loader{
+		environment: "test",
+		flags:       fs,
+		options:     o,
+		paths:       o.searchPaths,
+		service:     "svc",
+	}
+	cfg := &flagConfig{}
+	ld, err := l.load(cfg)
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	want := &flagConfig{
+		Timeout: 2 * time.Second,
+		Workers: 3,
+		Hosts:   []string{"b", "c"},
+		Region:  flagRegion{Region: "flag"},
+		Nested:  &flagNested{Limit: 5},
+	}
+	if !reflect.DeepEqual(cfg, want) {
+		t.Errorf("config = %+v; want %+v", cfg, want)
+	}
+	for key, flag := range map[string]string{
+		"timeout":      "--timeout",
+		"workers":      "--workers",
+		"hosts":        "--hosts",
+		"region":       "--region",
+		"nested.limit": "--nested-limit",
+	} {
+		if s, _ := ld.provenance.Lookup(key); s != (Source{Kind: SourceFlag, Name: flag}) {
+			t.Errorf("source of %s = %s; want %s (%s)", key, s, SourceFlag, flag)
+		}
+	}
+}
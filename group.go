@@ -0,0 +1,298 @@
+package saddle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/signal"
+	"strings"
+	"sync"
+	"syscall"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/pflag"
+	"go.opentelemetry.io/otel"
+	"go.uber.org/zap"
+
+	log "github.com/captjt/saddle/pkg/logger"
+)
+
+type (
+	// Member contains a service joined to a process; constructed by Join and run by RunAll.
+	Member interface {
+		// Name returns the name of the member service.
+		Name() string
+
+		start(ctx context.Context, cmd *cobra.Command, global bool) (runner, error)
+		// group marks the member as a member of a group; its configuration section defaults to its name.
+		group()
+	}
+
+	// member contains a service along with the option(s) it was joined with.
+	member[T Service] struct {
+		options *options
+		service T
+	}
+
+	// runner contains a started project; lets projects of different service types be supervised together.
+	runner interface {
+		// serve listens for incoming requests until the project is stopped.
+		serve() error
+		// stop stops the project, releasing the resource(s) of the service.
+		stop() error
+		// adminAddr returns the address of the admin listener; empty when there is none.
+		adminAddr() string
+	}
+)
+
+// Join joins the referenced service so it can be run by RunAll (or a Group) alongside other service(s); every member
+// loads its own configuration (see WithConfigSection) and constructs its own logger, tracer provider and Fiber app.
+func Join[T Service](service T, opts ...Option) Member {
+	o := newOptions(opts...)
+	register(service, o)
+	return &member[T]{
+		options: o,
+		service: service,
+	}
+}
+
+// Name returns the name of the member service.
+func (m *member[T]) Name() string {
+	return m.service.Name()
+}
+
+// group marks the member as a member of a group; its configuration section defaults to its name (see
+// WithConfigSection).
+func (m *member[T]) group() {
+	if m.options.section == "" {
+		m.options.section = m.service.Name()
+	}
+}
+
+// start loads the configuration of the member service and attaches it to a new project whose root context is derived
+// from the referenced context; global reports whether the tracer provider of the project is registered as the global
+// (otel) tracer provider.
+func (m *member[T]) start(ctx context.Context, cmd *cobra.Command, global bool) (runner, error) {
+	name := m.service.Name()
+
+	l := newLoader(name, cmd.Flags(), m.options)
+	ld, err := l.load(m.service.Config())
+	if err != nil {
+		return nil, err
+	}
+	env, address := l.environment, ld.viper.GetString(flagKey(name, addressFlag))
+	if address == "" {
+		return nil, fmt.Errorf("no address configured for %s; set %s", name, flagKey(name, addressFlag))
+	}
+
+	// - resolve admin address; the namespaced key of the member takes precedence over the shared saddle.admin.address ↴
+	adminAddress := ld.config.Saddle.Admin.Address
+	if a := ld.viper.GetString(flagKey(name, adminAddressKey)); a != "" {
+		adminAddress = a
+	}
+
+	// display service name, environment and description
+	fmt.Printf("%s [%s]\n   ⤷ %s\n\n", name, env, m.service.Description())
+
+	// construct logger for proper env and service; never log resolved secret(s)
+	lg := log.New(log.Environment(env), name)
+	lg.Redact(secretValues(ld.secrets)...)
+	logger.SetEnvironment(log.Environment(env), cmd.Name())
+	logger.Redact(secretValues(ld.secrets)...)
+
+	// - construct open-telemetry tracer provider from the configured exporter ↴
+	tp, err := tracerProvider(context.Background(), ld.config, m.service, env)
+	if err != nil {
+		lg.Sync()
+		return nil, fmt.Errorf("unable to construct tracer provider of %s: %w", name, err)
+	}
+	if global {
+		otel.SetTracerProvider(tp)
+	}
+
+	// - instantiate new service ↴
+	p, err := new(ctx, m.service, address, adminAddress, l, ld, tp, lg, m.service.Validator())
+	if err != nil {
+		// release whatever the service acquired before failing to attach | start
+		return nil, errors.Join(fmt.Errorf("%s: %w", name, err), p.stop())
+	}
+
+	// - watch configuration file(s) | SIGHUP for reloadable service(s) ↴
+	if _, ok := any(m.service).(Reloadable); ok {
+		if err := p.watch(lg); err != nil {
+			lg.Error("unable to watch configuration; reload disabled",
+				zap.Error(err),
+			)
+		}
+	}
+	return p, nil
+}
+
+// Group constructs a command running the referenced member service(s) concurrently within one process (e.g. an
+// internal admin API alongside a public API). The standard flag(s) of Command are registered, except --address which
+// is replaced by an --<name>-address flag per member bound to <name>.address. An --<name>-admin-address flag per
+// member is bound to <name>.admin.address, as member(s) cannot share saddle.admin.address. Every member deserializes
+// its configuration from the section of its name unless joined with WithConfigSection (e.g. <name>.*, with
+// <name>.saddle.* taking precedence over the shared saddle.*).
+func Group(name string, members ...Member) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   name,
+		Short: fmt.Sprintf("%s service group", name),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			return RunAll(cmd, members...)
+		},
+	}
+	pf := standardFlags(cmd)
+
+	// - define per-member command-line parameters ↴
+	for _, m := range members {
+		m.group()
+
+		f := fmt.Sprintf("%s-%s", m.Name(), addressFlag)
+		pf.String(f, "", fmt.Sprintf("address | interface %s listens on for incoming requests", m.Name()))
+		_ = pf.SetAnnotation(f, configKeyAnnotation, []string{flagKey(m.Name(), addressFlag)})
+
+		f = fmt.Sprintf("%s-admin-%s", m.Name(), addressFlag)
+		pf.String(f, "", fmt.Sprintf("address | interface %s listens on for admin requests", m.Name()))
+		_ = pf.SetAnnotation(f, configKeyAnnotation, []string{flagKey(m.Name(), adminAddressKey)})
+	}
+	return cmd
+}
+
+// RunAll starts the referenced member service(s) concurrently using the flag(s) of the referenced command, then blocks
+// until a member stops or the process receives SIGINT | SIGTERM; every member is then stopped together (see
+// Project.stop). The error(s) of the member(s) are returned joined; use ExitCode to derive the process exit code.
+func RunAll(cmd *cobra.Command, members ...Member) error {
+	if len(members) == 0 {
+		return errors.New("no service(s) to run")
+	}
+	defer logger.Sync() // flush any pending log(s) before returning
+	// configuration | runtime problem(s) are not usage problem(s); only report the error
+	cmd.SilenceUsage = true
+
+	// display project logo
+	logo.Print()
+	fmt.Println()
+
+	// - handle SIGINT | SIGTERM before any member starts; cancel the root context of every member on signal ↴
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, os.Interrupt, syscall.SIGTERM)
+	defer signal.Stop(signals)
+
+	signaled := make(chan os.Signal, 1)
+	go func() {
+		select {
+		case s := <-signals:
+			cancel()
+			signaled <- s
+		case <-ctx.Done():
+		}
+	}()
+
+	// - start member(s); stop those already started should any fail, share an admin address or a signal arrive ↴
+	var runners []runner
+	admins := map[string]string{} // admin address ⇢ member name
+	for i, m := range members {
+		if ctx.Err() != nil {
+			logger.Info("received signal; stopping service(s)")
+			return stop(runners)
+		}
+		r, err := m.start(ctx, cmd, i == 0)
+		if err != nil {
+			return errors.Join(err, stop(runners))
+		}
+		runners = append(runners, r)
+
+		if a := r.adminAddr(); a != "" {
+			if other, ok := admins[a]; ok {
+				err := fmt.Errorf("%s: admin address %s is used by %s; set %s", m.Name(), a, other,
+					flagKey(m.Name(), adminAddressKey))
+				return errors.Join(err, stop(runners))
+			}
+			admins[a] = m.Name()
+		}
+	}
+	otel.SetTextMapPropagator(propagator)
+
+	return supervise(runners, signaled)
+}
+
+// supervise serves the referenced runner(s) concurrently until one stops or a signal is received, then stops every
+// runner; the error(s) returned by serving are returned joined.
+func supervise(runners []runner, signals <-chan os.Signal) error {
+	errs := make(chan error, len(runners))
+	for _, r := range runners {
+		go func(r runner) {
+			errs <- r.serve()
+		}(r)
+	}
+
+	var results []error
+	pending := len(runners)
+	select {
+	case s := <-signals:
+		logger.Info("received signal; stopping service(s)",
+			zap.Stringer("signal", s),
+		)
+	case err := <-errs:
+		pending--
+		logger.Error("service stopped; stopping remaining service(s)",
+			zap.Error(err),
+		)
+		results = append(results, err)
+	}
+
+	// - stop every runner; collect the result of those still serving ↴
+	results = append(results, stop(runners))
+	for ; pending > 0; pending-- {
+		results = append(results, <-errs)
+	}
+	return errors.Join(results...)
+}
+
+// stop stops the referenced runner(s) concurrently, returning the joined error(s) once every runner has stopped.
+func stop(runners []runner) error {
+	var wg sync.WaitGroup
+	errs := make([]error, len(runners))
+	for i, r := range runners {
+		wg.Add(1)
+		go func(i int, r runner) {
+			defer wg.Done()
+			errs[i] = r.stop()
+		}(i, r)
+	}
+	wg.Wait()
+	return errors.Join(errs...)
+}
+
+// standardFlags defines the standard flag(s) shared by the command of every service | group: --environment (seeded
+// from the ENVIRONMENT environment variable and required), --config and --config-path.
+func standardFlags(cmd *cobra.Command) *pflag.FlagSet {
+	pf := cmd.PersistentFlags()
+	defineStandardFlags(pf)
+	seedFlag(cmd, environmentFlag)
+	return pf
+}
+
+// defineStandardFlags defines the standard flag(s) within the referenced flag set: --environment, --config and
+// --config-path.
+func defineStandardFlags(fs *pflag.FlagSet) {
+	// - define standard command-line parameters ↴
+	fs.StringP(environmentFlag, "e", "", "environment of service deployment")
+
+	// - define configuration command-line parameters ↴
+	fs.StringP(configFlag, "c", "", "explicit configuration file merged above the search path(s)")
+	fs.StringSlice(configPathFlag, nil, "ordered path(s) searched for configuration file(s)")
+}
+
+// seedFlag seeds the referenced persistent flag from its (upper-cased) environment variable and marks it required; a
+// flag is only required when its environment variable is absent.
+func seedFlag(cmd *cobra.Command, flag string) {
+	if v := os.Getenv(strings.ToUpper(flag)); v != "" {
+		_ = cmd.PersistentFlags().Set(flag, v)
+	}
+	_ = cmd.MarkPersistentFlagRequired(flag)
+}
@@ -0,0 +1,229 @@
+package saddle
+
+import (
+	"context"
+	"errors"
+	"io"
+	"net/http"
+	"os"
+	"reflect"
+	"strings"
+	"syscall"
+	"testing"
+	"testing/fstest"
+	"time"
+)
+
+type (
+	// namedService contains a service of the referenced name, so several can be joined to a group.
+	namedService struct {
+		*lifecycleService
+		name string
+	}
+
+	// startingService contains a service whose Start hook blocks until its context is cancelled.
+	startingService struct {
+		*lifecycleService
+
+		starting chan struct{}
+		startErr chan error
+	}
+)
+
+func (s *namedService) Name() string { return s.name }
+
+func (s *startingService) Start(ctx context.Context) error {
+	close(s.starting)
+	<-ctx.Done()
+	s.startErr <- ctx.Err()
+	return ctx.Err()
+}
+
+func TestRunAllSignalWhileStarting(t *testing.T) {
+	svc := &startingService{
+		lifecycleService: newLifecycleService(),
+		starting:         make(chan struct{}),
+		startErr:         make(chan error, 1),
+	}
+	cmd := Group("group", Join(svc,
+		WithFS(fstest.MapFS{"test.yaml": &fstest.MapFile{Data: []byte(`name: svc`)}}),
+		WithSearchPaths(t.TempDir()),
+	))
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--environment", "test", "--svc-address", freeAddress(t)})
+
+	ran := make(chan error, 1)
+	go func() {
+		ran <- cmd.Execute()
+	}()
+
+	// - interrupt the process while the member starts; the signal is handled by RunAll ↴
+	select {
+	case <-svc.starting:
+	case err := <-ran:
+		t.Fatalf("RunAll returned before starting: %v", err)
+	}
+	if err := syscall.Kill(os.Getpid(), syscall.SIGINT); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case err := <-svc.startErr:
+		if !errors.Is(err, context.Canceled) {
+			t.Errorf("start context error = %v; want %v", err, context.Canceled)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("signal did not cancel the start of the member")
+	}
+	select {
+	case <-ran:
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunAll did not return")
+	}
+	// the attached member is shut down, but never stopped as it did not start
+	if want := []string{"shutdown"}; !reflect.DeepEqual(svc.recorded(), want) {
+		t.Errorf("events = %v; want %v", svc.recorded(), want)
+	}
+}
+
+func TestRunAllAdminAddress(t *testing.T) {
+	shared := freeAddress(t)
+
+	tests := []struct {
+		name    string
+		admins  []string
+		wantErr string
+	}{
+		{
+			name:    "members share saddle.admin.address",
+			wantErr: "admin address " + shared + " is used by a; set b.admin.address",
+		},
+		{
+			name:   "members override saddle.admin.address",
+			admins: []string{freeAddress(t), freeAddress(t)},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := "saddle: {admin: {address: " + shared + "}}"
+			opts := []Option{
+				WithFS(fstest.MapFS{"test.yaml": &fstest.MapFile{Data: []byte(config)}}),
+				WithSearchPaths(t.TempDir()),
+			}
+			a := &namedService{lifecycleService: newLifecycleService(), name: "a"}
+			b := &namedService{lifecycleService: newLifecycleService(), name: "b"}
+			cmd := Group("group", Join(a, opts...), Join(b, opts...))
+			cmd.SetErr(io.Discard)
+			args := []string{"--environment", "test", "--a-address", freeAddress(t), "--b-address", freeAddress(t)}
+			if tt.admins != nil {
+				args = append(args, "--a-admin-address", tt.admins[0], "--b-admin-address", tt.admins[1])
+			}
+			cmd.SetArgs(args)
+
+			ran := make(chan error, 1)
+			go func() {
+				ran <- cmd.Execute()
+			}()
+
+			if tt.wantErr != "" {
+				select {
+				case err := <-ran:
+					if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+						t.Errorf("RunAll error = %v; want %q", err, tt.wantErr)
+					}
+				case <-time.After(5 * time.Second):
+					t.Fatal("RunAll did not return")
+				}
+				return
+			}
+
+			// - every member answers probe(s) on its own admin address ↴
+			for _, address := range tt.admins {
+				for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+					if time.Now().After(deadline) {
+						t.Fatalf("admin address %s was not served", address)
+					}
+					resp, err := http.Get("http://" + address + "/healthz")
+					if err == nil {
+						resp.Body.Close()
+						break
+					}
+				}
+			}
+
+			if err := syscall.Kill(os.Getpid(), syscall.SIGINT); err != nil {
+				t.Fatal(err)
+			}
+			select {
+			case err := <-ran:
+				if err != nil {
+					t.Errorf("RunAll: %v", err)
+				}
+			case <-time.After(5 * time.Second):
+				t.Fatal("RunAll did not return")
+			}
+		})
+	}
+}
+
+func TestRunAllConflictingKeys(t *testing.T) {
+	admins := []string{freeAddress(t), freeAddress(t)}
+	// both member(s) configure name (and saddle.admin) within the same file(s); each reads its own section
+	config := `
+name: root
+saddle: {admin: {address: ` + freeAddress(t) + `}}
+a: {name: alpha, saddle: {admin: {address: ` + admins[0] + `}}}
+b: {name: beta, saddle: {admin: {address: ` + admins[1] + `}}}`
+	opts := []Option{
+		WithFS(fstest.MapFS{"test.yaml": &fstest.MapFile{Data: []byte(config)}}),
+		WithSearchPaths(t.TempDir()),
+	}
+	a := &namedService{lifecycleService: newLifecycleService(), name: "a"}
+	b := &namedService{lifecycleService: newLifecycleService(), name: "b"}
+	cmd := Group("group", Join(a, opts...), Join(b, opts...))
+	cmd.SetErr(io.Discard)
+	cmd.SetArgs([]string{"--environment", "test", "--a-address", freeAddress(t), "--b-address", freeAddress(t)})
+
+	ran := make(chan error, 1)
+	go func() {
+		ran <- cmd.Execute()
+	}()
+
+	// - every member answers probe(s) on the admin address of its section ↴
+	for _, address := range admins {
+		for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+			select {
+			case err := <-ran:
+				t.Fatalf("RunAll returned before serving: %v", err)
+			default:
+			}
+			if time.Now().After(deadline) {
+				t.Fatalf("admin address %s was not served", address)
+			}
+			resp, err := http.Get("http://" + address + "/healthz")
+			if err == nil {
+				resp.Body.Close()
+				break
+			}
+		}
+	}
+
+	if err := syscall.Kill(os.Getpid(), syscall.SIGINT); err != nil {
+		t.Fatal(err)
+	}
+	select {
+	case err := <-ran:
+		if err != nil {
+			t.Errorf("RunAll: %v", err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("RunAll did not return")
+	}
+
+	for svc, want := range map[*namedService]string{a: "alpha", b: "beta"} {
+		if got := svc.Config().(*testConfig).Name; got != want {
+			t.Errorf("name of %s = %q; want %q", svc.name, got, want)
+		}
+	}
+}
@@ -7,8 +7,8 @@ import (
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
-	"github.com/labstack/echo/v4"
 
+	"github.com/captjt/saddle/models"
 	log "github.com/captjt/saddle/pkg/logger"
 )
 
@@ -26,12 +26,22 @@ type (
 		GitBranch  string
 		GitCommit  string
 		Version    string
+		// Reloads returns the outcome(s) of configuration reload(s); nil when the service is not reloadable.
+		Reloads func() *models.ReloadStatus
+		// Readiness returns the result of the readiness check(s) of the service; the service is ready when nil.
+		Readiness func() *models.ReadinessResponse
+		// Workers returns the state of each background worker of the service.
+		Workers func() []*models.WorkerStatus
+		// Startup returns the startup progress of the service; the service is started when nil.
+		Startup func() *models.StartupResponse
 	}
 )
 
 const (
-	healthEndpointURI = "/healthz"
-	statusEndpointURI = "/status"
+	healthEndpointURI  = "/healthz"
+	readyEndpointURI   = "/readyz"
+	startupEndpointURI = "/startupz"
+	statusEndpointURI  = "/status"
 )
 
 // healthCheckRegex contains parts of a request URL used to bypass metrics during calls to a health check endpoint.
@@ -53,13 +63,22 @@ func (h *handlers) Route(e *fiber.App, basePath string) {
 	g := e.Group(basePath)
 
 	g.Add(http.MethodGet, healthEndpointURI, h.getHealth())
+	g.Add(http.MethodGet, readyEndpointURI, h.getReadiness())
+	g.Add(http.MethodGet, startupEndpointURI, h.getStartup())
 	g.Add(http.MethodGet, statusEndpointURI, h.getStatus())
 }
 
-// Skipper is used for specifying which route(s) should be opted out by the open-telemetry collector.
-func Skipper(c echo.Context) bool {
-	if c.Path() == healthEndpointURI || c.Path() == statusEndpointURI {
+// Admin reports whether the request references a saddle-owned (admin) route; admin routes are served while the service
+// warms up.
+func Admin(c *fiber.Ctx) bool {
+	switch c.Path() {
+	case healthEndpointURI, readyEndpointURI, startupEndpointURI, statusEndpointURI:
 		return true
 	}
-	return healthCheckRegex.MatchString(c.Request().UserAgent())
+	return false
+}
+
+// Skipper is used for specifying which route(s) should be opted out by the open-telemetry collector.
+func Skipper(c *fiber.Ctx) bool {
+	return Admin(c) || healthCheckRegex.MatchString(c.Get(fiber.HeaderUserAgent))
 }
@@ -4,10 +4,52 @@ import (
 	"net/http"
 
 	"github.com/gofiber/fiber/v2"
+
+	"github.com/captjt/saddle/models"
 )
 
+// getHealth reports the liveness of the service; dependencies are never checked (see getReadiness).
 func (h *handlers) getHealth() fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		return c.SendStatus(http.StatusNoContent)
 	}
 }
+
+// getReadiness reports the readiness of the service; 503 is returned when any critical readiness check fails.
+func (h *handlers) getReadiness() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		resp := &models.ReadinessResponse{
+			Ready:  true,
+			Checks: []*models.CheckResult{},
+		}
+		if h.config.Readiness != nil {
+			resp = h.config.Readiness()
+		}
+
+		status := http.StatusOK
+		if !resp.Ready {
+			status = http.StatusServiceUnavailable
+		}
+		return c.Status(status).JSON(resp)
+	}
+}
+
+// getStartup reports the startup progress of the service; 503 is returned until the service attached and every warm-up
+// task finished successfully.
+func (h *handlers) getStartup() fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		resp := &models.StartupResponse{
+			Started: true,
+			WarmUps: []*models.WarmUpStatus{},
+		}
+		if h.config.Startup != nil {
+			resp = h.config.Startup()
+		}
+
+		status := http.StatusOK
+		if !resp.Started {
+			status = http.StatusServiceUnavailable
+		}
+		return c.Status(status).JSON(resp)
+	}
+}
@@ -18,12 +18,19 @@ func init() {
 
 func (h *handlers) getStatus() fiber.Handler {
 	return func(c *fiber.Ctx) error {
-		return c.Status(http.StatusOK).JSON(models.StatusResponse{
+		resp := models.StatusResponse{
 			Version:    h.config.Version,
 			CompiledAt: h.config.CompiledAt,
 			ExecutedAt: h.config.ExecutedAt.Format(time.RFC3339),
 			Uptime:     time.Now().UTC().Sub(h.config.ExecutedAt).String(),
 			BuildInfo:  info,
-		})
+		}
+		if h.config.Reloads != nil {
+			resp.Reload = h.config.Reloads()
+		}
+		if h.config.Workers != nil {
+			resp.Workers = h.config.Workers()
+		}
+		return c.Status(http.StatusOK).JSON(resp)
 	}
 }
@@ -0,0 +1,151 @@
+package saddle
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/captjt/saddle/models"
+)
+
+type (
+	// HealthChecker is implemented by dependencies of a service checked by /readyz (e.g. a database pool); services
+	// register checkers with their project (see Binder and Project.RegisterHealthCheck), typically during Attach.
+	HealthChecker interface {
+		// Name returns the name of the check.
+		Name() string
+		// Check checks the dependency; returning an error fails the check.
+		Check(ctx context.Context) error
+		// Timeout returns the maximum duration of the check; zero applies saddle.health.timeout.
+		Timeout() time.Duration
+		// Critical reports whether a failure of the check marks the service as not ready.
+		Critical() bool
+	}
+
+	// healthCheck contains a HealthChecker constructed from a check function (see NewHealthCheck).
+	healthCheck struct {
+		check    func(context.Context) error
+		critical bool
+		name     string
+		timeout  time.Duration
+	}
+
+	// health contains the health checker(s) registered by a service along with the cached result of checking them.
+	health struct {
+		mu        sync.Mutex
+		checkedAt time.Time
+		checkers  []HealthChecker
+		result    *models.ReadinessResponse
+	}
+)
+
+// NewHealthCheck constructs a HealthChecker from the referenced check function.
+func NewHealthCheck(name string, timeout time.Duration, critical bool, check func(context.Context) error) HealthChecker {
+	return &healthCheck{
+		check:    check,
+		critical: critical,
+		name:     name,
+		timeout:  timeout,
+	}
+}
+
+// Name returns the name of the check.
+func (h *healthCheck) Name() string {
+	return h.name
+}
+
+// Check runs the check function.
+func (h *healthCheck) Check(ctx context.Context) error {
+	return h.check(ctx)
+}
+
+// Timeout returns the maximum duration of the check.
+func (h *healthCheck) Timeout() time.Duration {
+	return h.timeout
+}
+
+// Critical reports whether a failure of the check marks the service as not ready.
+func (h *healthCheck) Critical() bool {
+	return h.critical
+}
+
+// RegisterHealthCheck registers the referenced checker(s) with the readiness check(s) of the project.
+func (p *Project[T]) RegisterHealthCheck(checkers ...HealthChecker) {
+	p.health.mu.Lock()
+	defer p.health.mu.Unlock()
+
+	p.health.checkers = append(p.health.checkers, checkers...)
+	p.health.result = nil
+}
+
+// readiness runs the registered checker(s) concurrently, reusing the previous result within saddle.health.cache_ttl;
+// concurrent request(s) wait for a single run rather than each checking the dependencies. A project is never ready
+// while it warms up.
+func (p *Project[T]) readiness() *models.ReadinessResponse {
+	if !p.isStarted() {
+		return &models.ReadinessResponse{
+			Checks: []*models.CheckResult{},
+		}
+	}
+
+	p.mu.RLock()
+	settings := p.loaded.config.Saddle.Health
+	p.mu.RUnlock()
+
+	h := p.health
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	if h.result != nil && time.Since(h.checkedAt) < settings.CacheTTL {
+		return h.result
+	}
+
+	resp := &models.ReadinessResponse{
+		Ready:  true,
+		Checks: make([]*models.CheckResult, len(h.checkers)),
+	}
+	var wg sync.WaitGroup
+	for i, c := range h.checkers {
+		wg.Add(1)
+		go func(i int, c HealthChecker) {
+			defer wg.Done()
+
+			timeout := c.Timeout()
+			if timeout <= 0 {
+				timeout = settings.Timeout
+			}
+			start := time.Now()
+			err := runHook(context.Background(), timeout, c.Check)
+
+			r := &models.CheckResult{
+				Name:     c.Name(),
+				Critical: c.Critical(),
+				Status:   models.CheckPass,
+				Duration: time.Since(start).String(),
+			}
+			if err != nil {
+				// check error(s) may quote connection string(s); never report a resolved secret
+				r.Status, r.Error = models.CheckFail, p.logger.Redacted(err.Error())
+				p.logger.Warn("readiness check failed",
+					zap.String("check", r.Name),
+					zap.Bool("critical", r.Critical),
+					zap.Error(err),
+				)
+			}
+			resp.Checks[i] = r
+		}(i, c)
+	}
+	wg.Wait()
+
+	for _, r := range resp.Checks {
+		if r.Critical && r.Status == models.CheckFail {
+			resp.Ready = false
+		}
+	}
+	h.checkedAt = time.Now()
+	resp.CheckedAt = h.checkedAt.UTC().Format(time.RFC3339)
+	h.result = resp
+	return resp
+}
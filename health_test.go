@@ -0,0 +1,211 @@
+package saddle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/captjt/saddle/models"
+)
+
+// startedProject constructs a test project of the referenced configuration which is started (i.e. warmed up) without
+// serving, so its readiness is checked.
+func startedProject(t *testing.T, config string) *Project[*lifecycleService] {
+	t.Helper()
+
+	p, err := testProject(t, newLifecycleService(), config)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	t.Cleanup(func() { _ = p.stop() })
+
+	p.startup.mu.Lock()
+	p.startup.started = true
+	p.startup.mu.Unlock()
+	return p
+}
+
+// getReadiness requests /readyz of the referenced project, returning the status code along with the decoded response.
+func getReadiness(t *testing.T, p *Project[*lifecycleService]) (int, *models.ReadinessResponse) {
+	t.Helper()
+
+	resp, err := p.App.Test(httptest.NewRequest(http.MethodGet, "/readyz", nil), 5000)
+	if err != nil {
+		t.Fatalf("request: %v", err)
+	}
+	defer resp.Body.Close()
+
+	r := &models.ReadinessResponse{}
+	if err := json.NewDecoder(resp.Body).Decode(r); err != nil {
+		t.Fatalf("decode: %v", err)
+	}
+	return resp.StatusCode, r
+}
+
+func TestReadiness(t *testing.T) {
+	failed := errors.New("connection refused")
+
+	// barrier returns a check passing only once every check of the barrier runs at the same time
+	barrier := func(n int) func(context.Context) error {
+		var wg sync.WaitGroup
+		wg.Add(n)
+		return func(ctx context.Context) error {
+			wg.Done()
+			done := make(chan struct{})
+			go func() {
+				wg.Wait()
+				close(done)
+			}()
+			select {
+			case <-done:
+				return nil
+			case <-ctx.Done():
+				return ctx.Err()
+			}
+		}
+	}
+	// blocking returns a check blocking until its deadline, failing when it has none
+	blocking := func(ctx context.Context) error {
+		if _, ok := ctx.Deadline(); !ok {
+			return errors.New("no deadline")
+		}
+		<-ctx.Done()
+		return ctx.Err()
+	}
+
+	tests := []struct {
+		name       string
+		config     string
+		checkers   func() []HealthChecker
+		wantStatus int
+		want       []*models.CheckResult
+	}{
+		{
+			name:       "no checks",
+			config:     `name: svc`,
+			checkers:   func() []HealthChecker { return nil },
+			wantStatus: http.StatusOK,
+			want:       []*models.CheckResult{},
+		},
+		{
+			name:   "checks run concurrently",
+			config: `name: svc`,
+			checkers: func() []HealthChecker {
+				check := barrier(2)
+				return []HealthChecker{
+					NewHealthCheck("db", time.Second, true, check),
+					NewHealthCheck("cache", time.Second, true, check),
+				}
+			},
+			wantStatus: http.StatusOK,
+			want: []*models.CheckResult{
+				{Name: "db", Critical: true, Status: models.CheckPass},
+				{Name: "cache", Critical: true, Status: models.CheckPass},
+			},
+		},
+		{
+			name:   "zero timeout applies saddle.health.timeout",
+			config: `saddle: {health: {timeout: 10ms}}`,
+			checkers: func() []HealthChecker {
+				return []HealthChecker{NewHealthCheck("db", 0, true, blocking)}
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			want: []*models.CheckResult{
+				{Name: "db", Critical: true, Status: models.CheckFail, Error: context.DeadlineExceeded.Error()},
+			},
+		},
+		{
+			name:   "non-critical check fails",
+			config: `name: svc`,
+			checkers: func() []HealthChecker {
+				return []HealthChecker{
+					NewHealthCheck("db", 0, true, func(context.Context) error { return nil }),
+					NewHealthCheck("cache", 0, false, func(context.Context) error { return failed }),
+				}
+			},
+			wantStatus: http.StatusOK,
+			want: []*models.CheckResult{
+				{Name: "db", Critical: true, Status: models.CheckPass},
+				{Name: "cache", Status: models.CheckFail, Error: failed.Error()},
+			},
+		},
+		{
+			name:   "critical check fails",
+			config: `name: svc`,
+			checkers: func() []HealthChecker {
+				return []HealthChecker{
+					NewHealthCheck("db", 0, true, func(context.Context) error { return failed }),
+					NewHealthCheck("cache", 0, false, func(context.Context) error { return nil }),
+				}
+			},
+			wantStatus: http.StatusServiceUnavailable,
+			want: []*models.CheckResult{
+				{Name: "db", Critical: true, Status: models.CheckFail, Error: failed.Error()},
+				{Name: "cache", Status: models.CheckPass},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := startedProject(t, tt.config)
+			p.RegisterHealthCheck(tt.checkers()...)
+
+			status, resp := getReadiness(t, p)
+			if status != tt.wantStatus {
+				t.Errorf("status = %d; want %d", status, tt.wantStatus)
+			}
+			if resp.Ready != (tt.wantStatus == http.StatusOK) {
+				t.Errorf("ready = %t; want %t", resp.Ready, tt.wantStatus == http.StatusOK)
+			}
+			if len(resp.Checks) != len(tt.want) {
+				t.Fatalf("checks = %d; want %d", len(resp.Checks), len(tt.want))
+			}
+			for i, got := range resp.Checks {
+				if got.Duration == "" {
+					t.Errorf("check %s reports no duration", got.Name)
+				}
+				got.Duration = ""
+				if *got != *tt.want[i] {
+					t.Errorf("check = %+v; want %+v", *got, *tt.want[i])
+				}
+			}
+		})
+	}
+}
+
+func TestReadinessCache(t *testing.T) {
+	p := startedProject(t, `saddle: {health: {cache_ttl: 500ms}}`)
+
+	var runs atomic.Int64
+	counting := func(context.Context) error {
+		runs.Add(1)
+		return nil
+	}
+	p.RegisterHealthCheck(NewHealthCheck("db", 0, true, counting))
+
+	first := p.readiness()
+	// - result(s) are reused within saddle.health.cache_ttl ↴
+	if second := p.readiness(); second != first || runs.Load() != 1 {
+		t.Errorf("runs = %d; want the cached result of 1 run", runs.Load())
+	}
+
+	// - registering a check invalidates the cached result ↴
+	p.RegisterHealthCheck(NewHealthCheck("cache", 0, false, counting))
+	if resp := p.readiness(); len(resp.Checks) != 2 || runs.Load() != 3 {
+		t.Errorf("checks = %d, runs = %d; want 2 checks of 3 runs", len(resp.Checks), runs.Load())
+	}
+
+	// - result(s) are checked again once saddle.health.cache_ttl elapses ↴
+	time.Sleep(550 * time.Millisecond)
+	p.readiness()
+	if runs.Load() != 5 {
+		t.Errorf("runs = %d; want 5", runs.Load())
+	}
+}
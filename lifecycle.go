@@ -0,0 +1,35 @@
+package saddle
+
+import (
+	"context"
+	"time"
+)
+
+// deadline returns a context derived from the referenced parent, cancelled once the referenced timeout elapses; zero
+// applies no deadline.
+func deadline(parent context.Context, timeout time.Duration) (context.Context, context.CancelFunc) {
+	if timeout <= 0 {
+		return context.WithCancel(parent)
+	}
+	return context.WithTimeout(parent, timeout)
+}
+
+// runHook runs the referenced lifecycle hook with a context derived from the referenced parent, abandoning it once the
+// parent is cancelled or the referenced timeout elapses (zero applies no timeout); a hook ignoring the cancellation of
+// its context cannot hold up startup | shutdown.
+func runHook(parent context.Context, timeout time.Duration, hook func(context.Context) error) error {
+	ctx, cancel := deadline(parent, timeout)
+	defer cancel()
+
+	done := make(chan error, 1)
+	go func() {
+		done <- hook(ctx)
+	}()
+
+	select {
+	case err := <-done:
+		return err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
@@ -0,0 +1,203 @@
+package saddle
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+)
+
+type (
+	// hookService contains a service recording the lifecycle hook(s) it implements; startErr | readyErr fail their hook.
+	hookService struct {
+		*lifecycleService
+
+		startErr error
+		readyErr error
+	}
+)
+
+func (s *hookService) Start(context.Context) error {
+	s.record("start")
+	return s.startErr
+}
+
+func (s *hookService) Ready(context.Context) error {
+	s.record("ready")
+	return s.readyErr
+}
+
+func (s *hookService) Stop(context.Context) error {
+	s.record("stop")
+	return nil
+}
+
+func TestProjectLifecycleHooks(t *testing.T) {
+	failed := errors.New("failed")
+
+	tests := []struct {
+		name         string
+		startErr     error
+		readyErr     error
+		wantNewErr   bool
+		wantServeErr bool
+		want         []string
+	}{
+		{
+			name: "hooks succeed",
+			want: []string{"start", "ready", "stop", "shutdown"},
+		},
+		{
+			// an unstarted service is not stopped; the shutdown returned by Attach still runs
+			name:       "start fails",
+			startErr:   failed,
+			wantNewErr: true,
+			want:       []string{"start", "shutdown"},
+		},
+		{
+			name:         "ready fails",
+			readyErr:     failed,
+			wantServeErr: true,
+			want:         []string{"start", "ready", "stop", "shutdown"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := &hookService{lifecycleService: newLifecycleService(), startErr: tt.startErr, readyErr: tt.readyErr}
+			p, err := testProject(t, svc, `name: svc`)
+			if (err != nil) != tt.wantNewErr {
+				t.Fatalf("new error = %v; want error %t", err, tt.wantNewErr)
+			}
+
+			switch {
+			case tt.wantNewErr:
+				if err := p.stop(); err != nil {
+					t.Errorf("stop: %v", err)
+				}
+			case tt.wantServeErr:
+				// a failed Ready hook stops serving on its own
+				if err := p.serve(); err == nil {
+					t.Error("serve error = nil; want error")
+				}
+				if err := p.stop(); err != nil {
+					t.Errorf("stop: %v", err)
+				}
+			default:
+				served := serveProject(t, p)
+				// warm-up precedes the Ready hook; stop once it ran
+				for deadline := time.Now().Add(5 * time.Second); len(svc.recorded()) < 2; {
+					if time.Now().After(deadline) {
+						t.Fatal("Ready hook was not called")
+					}
+					time.Sleep(10 * time.Millisecond)
+				}
+				if err := p.stop(); err != nil {
+					t.Errorf("stop: %v", err)
+				}
+				if err := <-served; err != nil {
+					t.Errorf("serve: %v", err)
+				}
+			}
+
+			if got := svc.recorded(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events = %v; want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRunHook(t *testing.T) {
+	failed := errors.New("failed")
+
+	tests := []struct {
+		name    string
+		timeout time.Duration
+		hook    func(context.Context) error
+		want    error
+	}{
+		{
+			name: "success",
+			hook: func(context.Context) error { return nil },
+		},
+		{
+			name: "failure",
+			hook: func(context.Context) error { return failed },
+			want: failed,
+		},
+		{
+			name:    "hook observes its deadline",
+			timeout: 10 * time.Millisecond,
+			hook: func(ctx context.Context) error {
+				<-ctx.Done()
+				return ctx.Err()
+			},
+			want: context.DeadlineExceeded,
+		},
+		{
+			// a hook ignoring its context is abandoned
+			name:    "hook ignores its deadline",
+			timeout: 10 * time.Millisecond,
+			hook: func(context.Context) error {
+				time.Sleep(time.Second)
+				return nil
+			},
+			want: context.DeadlineExceeded,
+		},
+		{
+			name: "zero timeout applies no deadline",
+			hook: func(ctx context.Context) error {
+				if _, ok := ctx.Deadline(); ok {
+					return failed
+				}
+				return nil
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if err := runHook(context.Background(), tt.timeout, tt.hook); !errors.Is(err, tt.want) {
+				t.Errorf("runHook = %v; want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestProjectStopWhileWarmingUp(t *testing.T) {
+	svc := &hookService{lifecycleService: newLifecycleService()}
+	p, err := testProject(t, svc, `name: svc`)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+
+	warming, cancelled := make(chan struct{}), make(chan error, 1)
+	p.RegisterWarmUp("tables", func(ctx context.Context) error {
+		close(warming)
+		<-ctx.Done()
+		cancelled <- ctx.Err()
+		return ctx.Err()
+	})
+
+	served := make(chan error, 1)
+	go func() {
+		served <- p.serve()
+	}()
+	<-warming
+
+	if err := p.stop(); err != nil {
+		t.Errorf("stop: %v", err)
+	}
+	// the warm-up task observes shutdown rather than its timeout
+	if err := <-cancelled; !errors.Is(err, context.Canceled) {
+		t.Errorf("warm-up context error = %v; want %v", err, context.Canceled)
+	}
+	// a stopped service is neither failed nor notified it is ready
+	if err := <-served; err != nil {
+		t.Errorf("serve: %v", err)
+	}
+	if want := []string{"start", "stop", "shutdown"}; !reflect.DeepEqual(svc.recorded(), want) {
+		t.Errorf("events = %v; want %v", svc.recorded(), want)
+	}
+}
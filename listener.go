@@ -0,0 +1,165 @@
+package saddle
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+const (
+	// unixScheme contains the address prefix of a unix domain socket listener (e.g. unix:///run/svc.sock).
+	unixScheme = "unix://"
+	// fdScheme contains the address prefix of a listener inherited via LISTEN_FDS (e.g. fd://3 | fd://http); see
+	// inheritedListener.
+	fdScheme = "fd://"
+	// listenFdsStart contains the first file descriptor passed via socket activation (SD_LISTEN_FDS_START).
+	listenFdsStart = 3
+)
+
+type (
+	// inheritedFile contains a listener file descriptor passed to the process via socket activation.
+	inheritedFile struct {
+		fd   int
+		name string
+		// used reports whether the descriptor was claimed by a project; a descriptor is only served once.
+		used bool
+	}
+)
+
+var (
+	// inherited contains the listener file descriptor(s) passed to the process; parsed once, as the LISTEN_*
+	// environment variable(s) are unset so child process(es) do not inherit them.
+	inherited struct {
+		once  sync.Once
+		mu    sync.Mutex
+		files []*inheritedFile
+		err   error
+	}
+)
+
+// listen constructs a listener on the referenced address: unix:// addresses listen on a unix domain socket created with
+// the referenced mode, fd:// addresses claim a listener inherited via LISTEN_FDS, and any other address listens
+// on the referenced network.
+func listen(network, address string, socketMode os.FileMode) (net.Listener, error) {
+	switch {
+	case strings.HasPrefix(address, unixScheme):
+		return listenUnix(strings.TrimPrefix(address, unixScheme), socketMode)
+	case strings.HasPrefix(address, fdScheme):
+		return inheritedListener(strings.TrimPrefix(address, fdScheme))
+	}
+	return net.Listen(network, address)
+}
+
+// isTCP reports whether the referenced address is a TCP address rather than a unix domain socket | inherited listener.
+func isTCP(address string) bool {
+	return !strings.HasPrefix(address, unixScheme) && !strings.HasPrefix(address, fdScheme)
+}
+
+// listenUnix listens on the unix domain socket at the referenced path; a stale socket (i.e. one nothing accepts
+// connection(s) on) left behind by a previous process is removed first. The socket is removed once the listener is
+// closed.
+func listenUnix(path string, socketMode os.FileMode) (net.Listener, error) {
+	if path == "" {
+		return nil, errors.New("unix socket address requires a path (e.g. unix:///run/svc.sock)")
+	}
+	if socketMode&^os.ModePerm != 0 {
+		return nil, fmt.Errorf("invalid socket mode %#o; must be at most 0777", uint32(socketMode))
+	}
+
+	// - remove stale socket; never remove a socket in use or a file which is not a socket ↴
+	if fi, err := os.Lstat(path); err == nil {
+		if fi.Mode()&os.ModeSocket == 0 {
+			return nil, fmt.Errorf("%s exists and is not a unix socket", path)
+		}
+		if c, err := net.Dial("unix", path); err == nil {
+			c.Close()
+			return nil, fmt.Errorf("unix socket %s is in use", path)
+		}
+		if err := os.Remove(path); err != nil {
+			return nil, fmt.Errorf("unable to remove stale unix socket %s: %w", path, err)
+		}
+	}
+
+	ln, err := net.Listen("unix", path)
+	if err != nil {
+		return nil, err
+	}
+	if err := os.Chmod(path, socketMode); err != nil {
+		ln.Close()
+		return nil, fmt.Errorf("unable to set mode of unix socket %s: %w", path, err)
+	}
+	return ln, nil
+}
+
+// inheritedListener claims the listener inherited via socket activation (LISTEN_PID, LISTEN_FDS and LISTEN_FDNAMES)
+// referenced by file descriptor number (e.g. 3) or name (e.g. FileDescriptorName= of a systemd socket unit); the first
+// unclaimed listener is claimed when no reference is given.
+func inheritedListener(ref string) (net.Listener, error) {
+	inherited.once.Do(func() {
+		inherited.files, inherited.err = listenFds()
+	})
+	if inherited.err != nil {
+		return nil, inherited.err
+	}
+
+	inherited.mu.Lock()
+	defer inherited.mu.Unlock()
+
+	for _, f := range inherited.files {
+		if f.used || (ref != "" && ref != strconv.Itoa(f.fd) && ref != f.name) {
+			continue
+		}
+		f.used = true
+
+		// the listener duplicates the descriptor; close the original
+		file := os.NewFile(uintptr(f.fd), f.name)
+		defer file.Close()
+		ln, err := net.FileListener(file)
+		if err != nil {
+			return nil, fmt.Errorf("unable to listen on inherited file descriptor %d: %w", f.fd, err)
+		}
+		return ln, nil
+	}
+	if ref == "" {
+		return nil, errors.New("no unclaimed listener inherited via LISTEN_FDS")
+	}
+	return nil, fmt.Errorf("no unclaimed listener %s inherited via LISTEN_FDS", ref)
+}
+
+// listenFds returns the listener file descriptor(s) passed to the process via socket activation, then unsets the
+// LISTEN_* environment variable(s); none are returned when the descriptor(s) were passed to another process.
+func listenFds() ([]*inheritedFile, error) {
+	defer func() {
+		os.Unsetenv("LISTEN_PID")
+		os.Unsetenv("LISTEN_FDS")
+		os.Unsetenv("LISTEN_FDNAMES")
+	}()
+
+	if pid, err := strconv.Atoi(os.Getenv("LISTEN_PID")); err != nil || pid != os.Getpid() {
+		return nil, nil
+	}
+	n, err := strconv.Atoi(os.Getenv("LISTEN_FDS"))
+	if err != nil || n < 0 {
+		return nil, fmt.Errorf("invalid LISTEN_FDS %q", os.Getenv("LISTEN_FDS"))
+	}
+
+	var names []string
+	if v := os.Getenv("LISTEN_FDNAMES"); v != "" {
+		names = strings.Split(v, ":")
+	}
+
+	files := make([]*inheritedFile, 0, n)
+	for i := 0; i < n; i++ {
+		fd := listenFdsStart + i
+		f := &inheritedFile{fd: fd, name: fmt.Sprintf("LISTEN_FD_%d", fd)}
+		if i < len(names) && names[i] != "" {
+			f.name = names[i]
+		}
+		files = append(files, f)
+	}
+	return files, nil
+}
@@ -0,0 +1,222 @@
+package saddle
+
+import (
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestLoadSocketMode(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      string
+		want        os.FileMode
+		wantProblem map[string]string
+	}{
+		{name: "default", config: `saddle: {}`, want: 0o660},
+		{name: "unquoted octal literal", config: `saddle: {server: {socket_mode: 0600}}`, want: 0o600},
+		{name: "quoted octal", config: `saddle: {server: {socket_mode: "0600"}}`, want: 0o600},
+		{name: "quoted 0o octal", config: `saddle: {server: {socket_mode: "0o640"}}`, want: 0o640},
+		{
+			// decimal 660 is not the octal mode 0660 and exceeds 0777
+			name:        "decimal",
+			config:      `saddle: {server: {socket_mode: 660}}`,
+			wantProblem: map[string]string{"saddle.server.socket_mode": "max"},
+		},
+		{
+			name:        "beyond permission bits",
+			config:      `saddle: {server: {socket_mode: "01777"}}`,
+			wantProblem: map[string]string{"saddle.server.socket_mode": "max"},
+		},
+		{
+			name:        "not octal",
+			config:      `saddle: {server: {socket_mode: "0999"}}`,
+			wantProblem: map[string]string{"saddle.server.socket_mode": ""},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ld, ce := testLoad(t, map[string]string{"test.yaml": tt.config}, "test", &testConfig{})
+			if tt.wantProblem != nil {
+				if got := problemKeys(ce); !reflect.DeepEqual(got, tt.wantProblem) {
+					t.Errorf("problems = %v; want %v", got, tt.wantProblem)
+				}
+				return
+			}
+			if ce != nil {
+				t.Fatalf("load: %v", ce)
+			}
+			if got := ld.config.Saddle.Server.SocketMode; got != tt.want {
+				t.Errorf("socket mode = %#o; want %#o", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestListenUnix(t *testing.T) {
+	tests := []struct {
+		name    string
+		prepare func(t *testing.T, path string)
+		mode    os.FileMode
+		wantErr string
+	}{
+		{
+			name: "new socket",
+			mode: 0o600,
+		},
+		{
+			name: "stale socket",
+			prepare: func(t *testing.T, path string) {
+				ln, err := net.Listen("unix", path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				// leave the socket file behind, as a killed process does
+				ln.(*net.UnixListener).SetUnlinkOnClose(false)
+				ln.Close()
+			},
+			mode: 0o660,
+		},
+		{
+			name: "socket in use",
+			prepare: func(t *testing.T, path string) {
+				ln, err := net.Listen("unix", path)
+				if err != nil {
+					t.Fatal(err)
+				}
+				t.Cleanup(func() { ln.Close() })
+			},
+			mode:    0o660,
+			wantErr: "in use",
+		},
+		{
+			name: "not a socket",
+			prepare: func(t *testing.T, path string) {
+				if err := os.WriteFile(path, nil, 0o600); err != nil {
+					t.Fatal(err)
+				}
+			},
+			mode:    0o660,
+			wantErr: "not a unix socket",
+		},
+		{
+			name:    "invalid mode",
+			mode:    os.ModeSetuid | 0o660,
+			wantErr: "invalid socket mode",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "svc.sock")
+			if tt.prepare != nil {
+				tt.prepare(t, path)
+			}
+
+			ln, err := listen("tcp", unixScheme+path, tt.mode)
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v; want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("listen: %v", err)
+			}
+
+			fi, err := os.Stat(path)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := fi.Mode().Perm(); got != tt.mode {
+				t.Errorf("mode = %#o; want %#o", got, tt.mode)
+			}
+
+			// the socket is removed once the listener is closed
+			ln.Close()
+			if _, err := os.Stat(path); !os.IsNotExist(err) {
+				t.Errorf("socket remains after close: %v", err)
+			}
+		})
+	}
+}
+
+func TestListenFds(t *testing.T) {
+	pid := strconv.Itoa(os.Getpid())
+
+	tests := []struct {
+		name    string
+		env     map[string]string
+		want    []inheritedFile
+		wantErr bool
+	}{
+		{
+			name: "named descriptors",
+			env:  map[string]string{"LISTEN_PID": pid, "LISTEN_FDS": "2", "LISTEN_FDNAMES": "http:"},
+			want: []inheritedFile{{fd: 3, name: "http"}, {fd: 4, name: "LISTEN_FD_4"}},
+		},
+		{
+			name: "descriptors of another process",
+			env:  map[string]string{"LISTEN_PID": "1", "LISTEN_FDS": "1"},
+		},
+		{
+			name: "not socket activated",
+		},
+		{
+			name:    "invalid count",
+			env:     map[string]string{"LISTEN_PID": pid, "LISTEN_FDS": "many"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for _, k := range []string{"LISTEN_PID", "LISTEN_FDS", "LISTEN_FDNAMES"} {
+				t.Setenv(k, tt.env[k])
+			}
+
+			files, err := listenFds()
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("error = %v; want error %t", err, tt.wantErr)
+			}
+			var got []inheritedFile
+			for _, f := range files {
+				got = append(got, *f)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("files = %+v; want %+v", got, tt.want)
+			}
+
+			// child process(es) must not inherit the descriptor(s)
+			for _, k := range []string{"LISTEN_PID", "LISTEN_FDS", "LISTEN_FDNAMES"} {
+				if v, ok := os.LookupEnv(k); ok {
+					t.Errorf("%s = %q; want unset", k, v)
+				}
+			}
+		})
+	}
+}
+
+func TestIsTCP(t *testing.T) {
+	tests := []struct {
+		address string
+		want    bool
+	}{
+		{address: ":8080", want: true},
+		{address: "127.0.0.1:8080", want: true},
+		{address: "unix:///run/svc.sock"},
+		{address: "fd://3"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.address, func(t *testing.T) {
+			if got := isTCP(tt.address); got != tt.want {
+				t.Errorf("isTCP(%q) = %t; want %t", tt.address, got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"context"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+// Context derives the context of each request from the referenced requests context, so handlers observe its
+// cancellation (e.g. once the drain deadline of the shutdown expires); the request context is cancelled once the
+// request is handled. The referenced root context, cancelled as soon as shutdown begins, is attached to the locals
+// (CTXShutdown) of the request so handlers learn of shutdown while in-flight request(s) drain.
+func Context(requests, root context.Context) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		ctx, cancel := context.WithCancel(requests)
+		defer cancel()
+
+		c.SetUserContext(ctx)
+		c.Locals(CTXShutdown, root)
+		return c.Next()
+	}
+}
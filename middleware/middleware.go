@@ -1,9 +1,14 @@
-// Package middleware contains saddle-level related middleware which utilizes the Echo framework.
+// Package middleware contains saddle-level related middleware which utilizes the Fiber framework.
 package middleware
 
 const (
 	// CTXRequest contains the key in which the request payload is attached and referenced to the request context.
 	CTXRequest = "ctxRequest"
+	// CTXLogger contains the key in which the request-scoped logger is attached and referenced to the request context.
+	CTXLogger = "ctxLogger"
 	// CTXRequestID contains the key in which the request id is attached and referenced to the request context.
 	CTXRequestID = "ctxRequestID"
+	// CTXShutdown contains the key in which the root context of the service, cancelled once shutdown begins, is
+	// attached and referenced to the request context.
+	CTXShutdown = "ctxShutdown"
 )
@@ -10,7 +10,9 @@ import (
 	log "github.com/captjt/saddle/pkg/logger"
 )
 
-// RequestLog creates a middleware that logs request start and end times, along with latency.
+// RequestLog creates a middleware that logs request start and end times, along with latency. A request-scoped logger,
+// which attaches the request ID and any trace context of the request to every logged message, is attached to the user
+// context (see logger.FromContext) and the locals (CTXLogger) of the request for handlers to log with.
 func RequestLog(logger *log.Logger) fiber.Handler {
 	return func(c *fiber.Ctx) error {
 		startTime := time.Now()
@@ -22,10 +24,14 @@ func RequestLog(logger *log.Logger) fiber.Handler {
 			c.Set("X-Request-ID", requestID)
 		}
 
+		// Correlate log(s) with the request ID and trace context of the request, if any
+		logger := logger.WithContext(c.UserContext()).With(zap.String("request_id", requestID))
+		c.SetUserContext(log.NewContext(c.UserContext(), logger))
+		c.Locals(CTXLogger, logger)
+
 		logger.Info("request received",
 			zap.String("path", c.Path()),
 			zap.String("method", c.Method()),
-			zap.String("request_id", requestID),
 		)
 
 		// Proceed with chain
@@ -35,7 +41,6 @@ func RequestLog(logger *log.Logger) fiber.Handler {
 		logger.Info("request finished",
 			zap.String("path", c.Path()),
 			zap.String("method", c.Method()),
-			zap.String("request_id", requestID),
 			zap.Int64("latency", latency),
 		)
 
@@ -0,0 +1,96 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+	"go.uber.org/zap/zaptest/observer"
+
+	log "github.com/captjt/saddle/pkg/logger"
+)
+
+func TestRequestLogHandlerLogger(t *testing.T) {
+	const (
+		traceID     = "4bf92f3577b34da6a3ce929d0e0e4736"
+		traceparent = "00-" + traceID + "-00f067aa0ba902b7-01"
+	)
+
+	tests := []struct {
+		name        string
+		traced      bool
+		traceparent string
+		wantTrace   bool
+		wantTraceID string
+	}{
+		{name: "propagated trace context", traced: true, traceparent: traceparent, wantTrace: true, wantTraceID: traceID},
+		{name: "new trace", traced: true, wantTrace: true},
+		{name: "tracing disabled"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			core, logs := observer.New(zapcore.DebugLevel)
+			logger := log.New(log.Production, "test", zap.WrapCore(func(zapcore.Core) zapcore.Core {
+				return core
+			}))
+
+			app := fiber.New()
+			if tt.traced {
+				app.Use(Trace(sdktrace.NewTracerProvider(), propagation.TraceContext{}, nil))
+			}
+			app.Use(RequestLog(logger))
+			app.Get("/", func(c *fiber.Ctx) error {
+				log.FromContext(c.UserContext()).Info("handled")
+				return c.SendStatus(fiber.StatusNoContent)
+			})
+
+			req := httptest.NewRequest(fiber.MethodGet, "/", nil)
+			req.Header.Set("X-Request-ID", "req-1")
+			if tt.traceparent != "" {
+				req.Header.Set("traceparent", tt.traceparent)
+			}
+			if _, err := app.Test(req); err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+
+			entries := logs.FilterMessage("handled").All()
+			if len(entries) != 1 {
+				t.Fatalf("got %d handler log line(s); want 1", len(entries))
+			}
+			fields := entries[0].ContextMap()
+
+			if fields["request_id"] != "req-1" {
+				t.Errorf("request_id = %v; want req-1", fields["request_id"])
+			}
+			got, ok := fields["trace_id"]
+			if ok != tt.wantTrace || (tt.wantTraceID != "" && got != tt.wantTraceID) {
+				t.Errorf("trace_id = %v (present %t); want %q (present %t)", got, ok, tt.wantTraceID, tt.wantTrace)
+			}
+			if _, ok := fields["span_id"]; ok != tt.wantTrace {
+				t.Errorf("span_id present = %t; want %t", ok, tt.wantTrace)
+			}
+		})
+	}
+}
+
+func TestFromContextWithoutLogger(t *testing.T) {
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		// a request not handled by RequestLog must still be able to log
+		log.FromContext(c.UserContext()).Info("handled")
+		return c.SendStatus(fiber.StatusNoContent)
+	})
+
+	resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+	if err != nil {
+		t.Fatalf("request failed: %v", err)
+	}
+	if resp.StatusCode != fiber.StatusNoContent {
+		t.Errorf("status = %d; want %d", resp.StatusCode, fiber.StatusNoContent)
+	}
+}
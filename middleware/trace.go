@@ -0,0 +1,105 @@
+package middleware
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+// tracerName contains the instrumentation name of the tracer which records server span(s).
+const tracerName = "github.com/captjt/saddle/middleware"
+
+type (
+	// headerCarrier adapts the headers of a request to an open-telemetry carrier; values are read from the incoming
+	// request headers and written to the outgoing response headers.
+	headerCarrier struct {
+		c *fiber.Ctx
+	}
+)
+
+// Get returns the value of the referenced incoming request header.
+func (h headerCarrier) Get(key string) string {
+	return h.c.Get(key)
+}
+
+// Set sets the value of the referenced outgoing response header.
+func (h headerCarrier) Set(key, value string) {
+	h.c.Set(key, value)
+}
+
+// Keys returns the keys of all incoming request headers.
+func (h headerCarrier) Keys() []string {
+	keys := make([]string, 0, len(h.c.GetReqHeaders()))
+	for k := range h.c.GetReqHeaders() {
+		keys = append(keys, k)
+	}
+	return keys
+}
+
+// Trace creates a middleware that records a server span for each incoming request; requests matching the referenced
+// skipper are not recorded. Any trace context (traceparent | tracestate | baggage) carried by the request is extracted
+// as the parent of the span, and the resulting trace context is echoed back within the response headers.
+func Trace(
+	provider trace.TracerProvider,
+	propagator propagation.TextMapPropagator,
+	skipper func(*fiber.Ctx) bool,
+) fiber.Handler {
+	tracer := provider.Tracer(tracerName)
+
+	return func(c *fiber.Ctx) error {
+		if skipper != nil && skipper(c) {
+			return c.Next()
+		}
+
+		carrier := headerCarrier{c: c}
+		ctx := propagator.Extract(c.UserContext(), carrier)
+
+		ctx, span := tracer.Start(ctx, fmt.Sprintf("%s %s", c.Method(), c.Path()),
+			trace.WithSpanKind(trace.SpanKindServer),
+			trace.WithAttributes(
+				semconv.HTTPRequestMethodKey.String(c.Method()),
+				semconv.URLPath(c.Path()),
+				semconv.URLScheme(c.Protocol()),
+				semconv.ServerAddress(c.Hostname()),
+				semconv.ClientAddress(c.IP()),
+				semconv.UserAgentOriginal(c.Get(fiber.HeaderUserAgent)),
+			),
+		)
+		defer span.End()
+
+		// Attach the span to the request context so handlers can create child span(s) and correlate log(s)
+		c.SetUserContext(ctx)
+		propagator.Inject(ctx, carrier)
+
+		// Proceed with chain
+		err := c.Next()
+
+		// The matched route is only known once the chain has been executed
+		route := c.Route().Path
+		status := c.Response().StatusCode()
+		if err != nil {
+			span.RecordError(err)
+
+			// The error handler has yet to write the response; resolve the status it will send
+			status = http.StatusInternalServerError
+			if fe, ok := err.(*fiber.Error); ok {
+				status = fe.Code
+			}
+		}
+		span.SetName(fmt.Sprintf("%s %s", c.Method(), route))
+		span.SetAttributes(
+			semconv.HTTPRoute(route),
+			semconv.HTTPResponseStatusCode(status),
+		)
+		if status >= http.StatusInternalServerError {
+			span.SetStatus(codes.Error, http.StatusText(status))
+		}
+
+		return err
+	}
+}
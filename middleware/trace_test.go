@@ -0,0 +1,122 @@
+package middleware
+
+import (
+	"errors"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gofiber/fiber/v2"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/codes"
+	"go.opentelemetry.io/otel/propagation"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.opentelemetry.io/otel/sdk/trace/tracetest"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"go.opentelemetry.io/otel/trace"
+)
+
+func TestTraceServerSpan(t *testing.T) {
+	tests := []struct {
+		name       string
+		path       string
+		skip       bool
+		wantName   string
+		wantRoute  string
+		wantStatus int
+		wantError  bool
+	}{
+		{
+			// the span is named by the route rather than the (high cardinality) path
+			name:       "success",
+			path:       "/users/42",
+			wantName:   "GET /users/:id",
+			wantRoute:  "/users/:id",
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			name:       "client error",
+			path:       "/missing/42",
+			wantName:   "GET /missing/:id",
+			wantRoute:  "/missing/:id",
+			wantStatus: fiber.StatusNotFound,
+		},
+		{
+			name:       "handler error",
+			path:       "/failing/42",
+			wantName:   "GET /failing/:id",
+			wantRoute:  "/failing/:id",
+			wantStatus: fiber.StatusInternalServerError,
+			wantError:  true,
+		},
+		{
+			name: "skipped",
+			path: "/users/42",
+			skip: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sr := tracetest.NewSpanRecorder()
+			tp := sdktrace.NewTracerProvider(sdktrace.WithSpanProcessor(sr))
+
+			app := fiber.New()
+			app.Use(Trace(tp, propagation.TraceContext{}, func(*fiber.Ctx) bool { return tt.skip }))
+			app.Get("/users/:id", func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+			app.Get("/missing/:id", func(c *fiber.Ctx) error {
+				return fiber.ErrNotFound
+			})
+			app.Get("/failing/:id", func(c *fiber.Ctx) error {
+				return errors.New("failed")
+			})
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, tt.path, nil))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+
+			spans := sr.Ended()
+			if tt.skip {
+				if len(spans) != 0 {
+					t.Errorf("spans = %d; want 0", len(spans))
+				}
+				return
+			}
+			if len(spans) != 1 {
+				t.Fatalf("spans = %d; want 1", len(spans))
+			}
+			s := spans[0]
+
+			if s.Name() != tt.wantName {
+				t.Errorf("name = %q; want %q", s.Name(), tt.wantName)
+			}
+			if s.SpanKind() != trace.SpanKindServer {
+				t.Errorf("kind = %v; want %v", s.SpanKind(), trace.SpanKindServer)
+			}
+			attrs := map[attribute.Key]attribute.Value{}
+			for _, a := range s.Attributes() {
+				attrs[a.Key] = a.Value
+			}
+			if got := attrs[semconv.HTTPRouteKey].AsString(); got != tt.wantRoute {
+				t.Errorf("route = %q; want %q", got, tt.wantRoute)
+			}
+			if got := attrs[semconv.HTTPResponseStatusCodeKey].AsInt64(); got != int64(tt.wantStatus) {
+				t.Errorf("status attribute = %d; want %d", got, tt.wantStatus)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("response status = %d; want %d", resp.StatusCode, tt.wantStatus)
+			}
+
+			// only server error(s) mark the span as failed
+			if got := s.Status().Code == codes.Error; got != tt.wantError {
+				t.Errorf("error status = %t; want %t", got, tt.wantError)
+			}
+			// the trace context is echoed back to the client
+			if resp.Header.Get("traceparent") == "" {
+				t.Error("traceparent response header is missing")
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+package middleware
+
+import (
+	"errors"
+	"strconv"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/captjt/saddle/models"
+)
+
+// warmingUpCode contains the error code of a request rejected while the service warms up.
+const warmingUpCode = "warming_up"
+
+var errWarmingUp = errors.New("service is warming up; retry later")
+
+// WarmUp rejects request(s) with 503 Service Unavailable and a Retry-After header until started reports the service
+// has warmed up; request(s) opted out by the skipper (e.g. probes) are always served.
+func WarmUp(started func() bool, skipper func(*fiber.Ctx) bool, retryAfter func() time.Duration) fiber.Handler {
+	return func(c *fiber.Ctx) error {
+		if started() || skipper(c) {
+			return c.Next()
+		}
+
+		c.Set(fiber.HeaderRetryAfter, strconv.Itoa(int(retryAfter().Round(time.Second).Seconds())))
+		return c.Status(fiber.StatusServiceUnavailable).JSON(models.NewErrorResponse(errWarmingUp, warmingUpCode))
+	}
+}
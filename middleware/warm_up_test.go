@@ -0,0 +1,64 @@
+package middleware
+
+import (
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+)
+
+func TestWarmUp(t *testing.T) {
+	tests := []struct {
+		name           string
+		started        bool
+		skip           bool
+		retryAfter     time.Duration
+		wantStatus     int
+		wantRetryAfter string
+	}{
+		{
+			name:       "started",
+			started:    true,
+			wantStatus: fiber.StatusOK,
+		},
+		{
+			// the delay is rounded to whole second(s)
+			name:           "warming up",
+			retryAfter:     2600 * time.Millisecond,
+			wantStatus:     fiber.StatusServiceUnavailable,
+			wantRetryAfter: "3",
+		},
+		{
+			name:       "skipped while warming up",
+			skip:       true,
+			retryAfter: time.Second,
+			wantStatus: fiber.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			app := fiber.New()
+			app.Use(WarmUp(
+				func() bool { return tt.started },
+				func(*fiber.Ctx) bool { return tt.skip },
+				func() time.Duration { return tt.retryAfter },
+			))
+			app.Get("/", func(c *fiber.Ctx) error {
+				return c.SendStatus(fiber.StatusOK)
+			})
+
+			resp, err := app.Test(httptest.NewRequest(fiber.MethodGet, "/", nil))
+			if err != nil {
+				t.Fatalf("request failed: %v", err)
+			}
+			if resp.StatusCode != tt.wantStatus {
+				t.Errorf("status = %d; want %d", resp.StatusCode, tt.wantStatus)
+			}
+			if got := resp.Header.Get(fiber.HeaderRetryAfter); got != tt.wantRetryAfter {
+				t.Errorf("Retry-After = %q; want %q", got, tt.wantRetryAfter)
+			}
+		})
+	}
+}
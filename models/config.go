@@ -1,21 +1,121 @@
 package models
 
+import (
+	"os"
+	"time"
+)
+
 type (
 	// Config contains the configuration(s) model for the saddled service.
 	Config struct {
-		// Saddle contains the configuration(s) model(s) for the saddled service.
+		// Saddle contains the configuration(s) model(s) for the saddled service; at most one trace exporter may be
+		// configured (the excluded_with tag(s) of the exporter(s) are enforced by a struct-level validation).
 		Saddle struct {
 			// CloudTrace contains the configuration(s) for the Google® Cloud Trace open-telemetry exporter.
-			CloudTrace *CloudTrace `mapstructure:"cloud_trace" validate:"omitempty,excluded_with=Jaeger StdOut"`
+			CloudTrace *CloudTrace `mapstructure:"cloud_trace" validate:"omitempty,excluded_with=Jaeger OTLP StdOut None"`
 			// Jaeger contains the configuration(s) for the Jaeger® open-telemetry exporter.
-			Jaeger *Jaeger `mapstructure:"jaeger" validate:"omitempty,excluded_with=CloudTrace StdOut"`
+			Jaeger *Jaeger `mapstructure:"jaeger" validate:"omitempty,excluded_with=CloudTrace OTLP StdOut None"`
+			// OTLP contains the configuration(s) for the OTLP open-telemetry exporter.
+			OTLP *OTLP `mapstructure:"otlp" validate:"omitempty,excluded_with=CloudTrace Jaeger StdOut None"`
 			// StdOut contains the configuration(s) for the stdout open-telemetry exporter.
-			StdOut *StdOut `mapstructure:"stdout" validate:"omitempty,excluded_with=CloudTrace Jaeger None"`
+			StdOut *StdOut `mapstructure:"stdout" validate:"omitempty,excluded_with=CloudTrace Jaeger OTLP None"`
 			// None contains the configuration(s) for no trace exporter.
-			None *None `mapstructure:"none" validate:"omitempty,excluded_with=CloudTrace Jaeger StdOut"`
+			None *None `mapstructure:"none" validate:"omitempty,excluded_with=CloudTrace Jaeger OTLP StdOut"`
+			// Admin contains the configuration(s) for the admin listener of the saddled service.
+			Admin Admin `mapstructure:"admin"`
+			// Health contains the configuration(s) for the readiness check(s) of the saddled service.
+			Health Health `mapstructure:"health"`
+			// Lifecycle contains the configuration(s) for the lifecycle hook(s) of the saddled service.
+			Lifecycle Lifecycle `mapstructure:"lifecycle"`
+			// Server contains the configuration(s) for the HTTP server of the saddled service.
+			Server Server `mapstructure:"server"`
+			// Shutdown contains the configuration(s) for the graceful shutdown of the saddled service.
+			Shutdown Shutdown `mapstructure:"shutdown"`
 		} `mapstructure:"saddle"`
 	}
 
+	// Admin contains the configuration(s) for the admin listener of the saddled service.
+	Admin struct {
+		// Address contains the address | interface (host:port, unix://<path> | fd://<fd|name>) of a second, plaintext
+		// listener hosting the saddle-owned endpoint(s) (e.g. /healthz, /status); the public listener then hosts the
+		// service route(s) only. The saddle-owned endpoint(s) are hosted by the public listener when omitted.
+		// Overridden by <name>.admin.address, so the member(s) of a group can listen on distinct admin addresses.
+		Address string `mapstructure:"address"`
+	}
+
+	// Health contains the configuration(s) for the readiness check(s) of the saddled service.
+	Health struct {
+		// CacheTTL contains the duration the result of the readiness check(s) is reused for; zero disables caching.
+		CacheTTL time.Duration `mapstructure:"cache_ttl" validate:"min=0" default:"2s"`
+		// Timeout contains the maximum duration of a readiness check which does not specify its own.
+		Timeout time.Duration `mapstructure:"timeout" validate:"min=0" default:"5s"`
+	}
+
+	// Lifecycle contains the configuration(s) for the lifecycle hook(s) of the saddled service; zero applies no timeout.
+	Lifecycle struct {
+		// StartTimeout contains the maximum duration of the Start hook.
+		StartTimeout time.Duration `mapstructure:"start_timeout" validate:"min=0" default:"30s"`
+		// WarmUpTimeout contains the maximum duration of the warm-up task(s) run once the listener is up.
+		WarmUpTimeout time.Duration `mapstructure:"warm_up_timeout" validate:"min=0" default:"2m"`
+		// RetryAfter contains the duration request(s) are asked to wait (Retry-After) while the service warms up.
+		RetryAfter time.Duration `mapstructure:"retry_after" validate:"min=0" default:"5s"`
+		// ReadyTimeout contains the maximum duration of the Ready hook.
+		ReadyTimeout time.Duration `mapstructure:"ready_timeout" validate:"min=0" default:"10s"`
+		// StopTimeout contains the maximum duration of the Stop hook, and of waiting for background worker(s) to return.
+		StopTimeout time.Duration `mapstructure:"stop_timeout" validate:"min=0" default:"10s"`
+	}
+
+	// Server contains the configuration(s) for the HTTP server of the saddled service; zero applies the Fiber default.
+	Server struct {
+		// ReadTimeout contains the maximum duration for reading a request, including the body.
+		ReadTimeout time.Duration `mapstructure:"read_timeout" validate:"min=0"`
+		// WriteTimeout contains the maximum duration before timing out the write of a response.
+		WriteTimeout time.Duration `mapstructure:"write_timeout" validate:"min=0"`
+		// IdleTimeout contains the maximum duration to wait for the next request on a keep-alive connection.
+		IdleTimeout time.Duration `mapstructure:"idle_timeout" validate:"min=0"`
+		// BodyLimit contains the maximum size (in bytes) of a request body.
+		BodyLimit int `mapstructure:"body_limit" validate:"min=0"`
+		// Concurrency contains the maximum number of concurrent connection(s).
+		Concurrency int `mapstructure:"concurrency" validate:"min=0"`
+		// ProxyHeader contains the header the client IP is read from (e.g. X-Forwarded-For).
+		ProxyHeader string `mapstructure:"proxy_header"`
+		// TrustedProxies contains the IP address(es) | CIDR range(s) trusted to set proxy header(s); proxy header(s) of
+		// other peer(s) are ignored when set.
+		TrustedProxies []string `mapstructure:"trusted_proxies" validate:"omitempty,dive,ip|cidr"`
+		// SocketMode contains the file mode of the unix domain socket listened on when the address is a unix://
+		// address; an octal literal (e.g. 0660, quoted or not) at most 0777. Defaults to 0660.
+		SocketMode os.FileMode `mapstructure:"socket_mode" validate:"max=0777" default:"0660"`
+		// Prefork spawns a child process per CPU, each listening on the address via SO_REUSEPORT; not supported with TLS
+		// or a unix:// | fd:// address.
+		Prefork bool `mapstructure:"prefork" validate:"excluded_with=TLS"`
+		// TLS contains the transport security configuration(s) of the listener; plaintext when omitted.
+		TLS *ServerTLS `mapstructure:"tls" validate:"omitempty"`
+	}
+
+	// ServerTLS contains the transport security configuration(s) of the listener of the saddled service.
+	ServerTLS struct {
+		// CertFile contains the path of the certificate presented to client(s); rotated file(s) are reloaded without a
+		// restart.
+		CertFile string `mapstructure:"cert_file" validate:"required,file"`
+		// KeyFile contains the path of the private key of the certificate.
+		KeyFile string `mapstructure:"key_file" validate:"required,file"`
+		// ClientCAFile contains the path of the certificate authority used to verify client certificate(s); required
+		// when client certificate(s) are verified.
+		ClientCAFile string `mapstructure:"client_ca_file" validate:"omitempty,file"`
+		// ClientAuth contains the client certificate policy (none | request | require | verify-if-given |
+		// require-and-verify); defaults to none.
+		ClientAuth string `mapstructure:"client_auth" validate:"omitempty,oneof=none request require verify-if-given require-and-verify" default:"none"`
+		// MinVersion contains the minimum TLS version accepted (1.2 | 1.3); defaults to 1.2.
+		MinVersion string `mapstructure:"min_version" validate:"omitempty,oneof=1.2 1.3" default:"1.2"`
+	}
+
+	// Shutdown contains the configuration(s) for the graceful shutdown of the saddled service.
+	Shutdown struct {
+		// DrainTimeout contains the maximum duration to wait for in-flight request(s) to complete once the service stops
+		// accepting connection(s); zero waits indefinitely.
+		DrainTimeout time.Duration `mapstructure:"drain_timeout" validate:"min=0" default:"10s"`
+	}
+
 	// CloudTrace contains the configuration(s) for the Google® Cloud Trace open-telemetry exporter.
 	CloudTrace struct {
 		// ProjectID is the Google Cloud Project identifier to export Cloud Tracing telemetry.
@@ -32,6 +132,37 @@ type (
 		SampleRate float64 `mapstructure:"sample_rate" validate:"required,min=0,max=100"`
 	}
 
+	// OTLP contains the configuration(s) for the OTLP open-telemetry exporter.
+	OTLP struct {
+		// Endpoint contains the URL of the OTLP collector in which to export; the OTLP/HTTP trace path is assumed when
+		// the URL does not specify one.
+		Endpoint string `mapstructure:"endpoint" validate:"required,uri"`
+		// Protocol contains the transport protocol of the OTLP collector; defaults to http/protobuf.
+		Protocol string `mapstructure:"protocol" validate:"omitempty,oneof=grpc http/protobuf" default:"http/protobuf"`
+		// Headers contains the header(s) to attach to each export request (e.g. authorization).
+		Headers map[string]string `mapstructure:"headers" secret:"true"`
+		// Insecure disables transport security when exporting.
+		Insecure bool `mapstructure:"insecure"`
+		// TLS contains the transport security configuration(s) when exporting.
+		TLS *OTLPTLS `mapstructure:"tls" validate:"omitempty,excluded_with=Insecure"`
+		// Compression contains the compression applied to each export request; defaults to none.
+		Compression string `mapstructure:"compression" validate:"omitempty,oneof=gzip none" default:"none"`
+		// SampleRate contains the percentage rate of total requests to collect and export.
+		SampleRate float64 `mapstructure:"sample_rate" validate:"required,min=0,max=100"`
+	}
+
+	// OTLPTLS contains the transport security configuration(s) for the OTLP open-telemetry exporter.
+	OTLPTLS struct {
+		// CAFile contains the path of the certificate authority used to verify the collector certificate.
+		CAFile string `mapstructure:"ca_file" validate:"omitempty,file"`
+		// CertFile contains the path of the client certificate presented to the collector.
+		CertFile string `mapstructure:"cert_file" validate:"required_with=KeyFile,omitempty,file"`
+		// KeyFile contains the path of the client key presented to the collector.
+		KeyFile string `mapstructure:"key_file" validate:"required_with=CertFile,omitempty,file"`
+		// ServerName contains the name used to verify the hostname of the collector certificate.
+		ServerName string `mapstructure:"server_name"`
+	}
+
 	StdOut struct {
 		// SampleRate contains the percentage rate of total requests to collect and export.
 		SampleRate float64 `mapstructure:"sample_rate" validate:"required,min=0,max=100"`
@@ -0,0 +1,63 @@
+package models
+
+const (
+	// CheckPass contains the status of a readiness check which passed.
+	CheckPass = "pass"
+	// CheckFail contains the status of a readiness check which failed.
+	CheckFail = "fail"
+
+	// WarmUpPending contains the status of a warm-up task which has not run yet.
+	WarmUpPending = "pending"
+	// WarmUpRunning contains the status of a running warm-up task.
+	WarmUpRunning = "running"
+	// WarmUpDone contains the status of a warm-up task which finished successfully.
+	WarmUpDone = "done"
+	// WarmUpFailed contains the status of a warm-up task which failed.
+	WarmUpFailed = "failed"
+)
+
+type (
+	// ReadinessResponse contains the response of a readiness request.
+	ReadinessResponse struct {
+		// Ready reports whether every critical readiness check passed.
+		Ready bool `json:"ready"`
+		// CheckedAt contains the datetime stamp representing when the readiness check(s) were run.
+		CheckedAt string `json:"checked_at,omitempty"`
+		// Checks contains the result of each readiness check.
+		Checks []*CheckResult `json:"checks"`
+	}
+
+	// StartupResponse contains the response of a startup request.
+	StartupResponse struct {
+		// Started reports whether the service attached and every warm-up task finished successfully.
+		Started bool `json:"started"`
+		// WarmUps contains the progress of each warm-up task.
+		WarmUps []*WarmUpStatus `json:"warm_ups"`
+	}
+
+	// WarmUpStatus contains the progress of a warm-up task.
+	WarmUpStatus struct {
+		// Name contains the name of the warm-up task.
+		Name string `json:"name"`
+		// Status contains the status of the warm-up task (pending | running | done | failed).
+		Status string `json:"status"`
+		// Error contains the error of a failed warm-up task, if any.
+		Error string `json:"error,omitempty"`
+		// Duration contains the duration of a finished warm-up task.
+		Duration string `json:"duration,omitempty"`
+	}
+
+	// CheckResult contains the result of a readiness check.
+	CheckResult struct {
+		// Name contains the name of the readiness check.
+		Name string `json:"name"`
+		// Critical reports whether a failure of the readiness check marks the service as not ready.
+		Critical bool `json:"critical"`
+		// Status contains the status of the readiness check (pass | fail).
+		Status string `json:"status"`
+		// Error contains the error of a failed readiness check, if any.
+		Error string `json:"error,omitempty"`
+		// Duration contains the duration of the readiness check.
+		Duration string `json:"duration"`
+	}
+)
@@ -17,5 +17,41 @@ type (
 		Uptime string `json:"uptime"`
 		// BuildInfo contains details about the build information of the compiled the service executable.
 		BuildInfo *debug.BuildInfo `json:"build_info"`
+		// Reload contains the outcome(s) of configuration reload(s); omitted when the service is not reloadable.
+		Reload *ReloadStatus `json:"reload,omitempty"`
+		// Workers contains the state of each background worker; omitted when the service runs none.
+		Workers []*WorkerStatus `json:"workers,omitempty"`
+	}
+
+	// WorkerStatus contains the state of a background worker.
+	WorkerStatus struct {
+		// Name contains the name of the worker.
+		Name string `json:"name"`
+		// State contains the state of the worker (running | backoff | completed | failed | stopped).
+		State string `json:"state"`
+		// RestartPolicy contains the restart policy of the worker (never | on-failure | always).
+		RestartPolicy string `json:"restart_policy"`
+		// Restarts contains the count of times the worker was restarted.
+		Restarts int `json:"restarts"`
+		// StartedAt contains the datetime stamp representing when the worker was last started.
+		StartedAt string `json:"started_at,omitempty"`
+		// LastError contains the error (or recovered panic) the worker last returned, if any.
+		LastError string `json:"last_error,omitempty"`
+	}
+
+	// ReloadStatus contains the outcome(s) of configuration reload(s).
+	ReloadStatus struct {
+		// Accepted contains the count of configuration reloads handed to the service.
+		Accepted int `json:"accepted"`
+		// Rejected contains the count of configuration reloads which failed validation or were refused by the service.
+		Rejected int `json:"rejected"`
+		// LastAttemptAt contains the datetime stamp representing when a reload was last attempted.
+		LastAttemptAt string `json:"last_attempt_at,omitempty"`
+		// LastTrigger contains what triggered the last reload attempt (file | signal).
+		LastTrigger string `json:"last_trigger,omitempty"`
+		// LastOutcome contains the outcome of the last reload attempt (accepted | rejected).
+		LastOutcome string `json:"last_outcome,omitempty"`
+		// LastError contains the error of the last rejected reload attempt, if any.
+		LastError string `json:"last_error,omitempty"`
 	}
 )
@@ -0,0 +1,74 @@
+package saddle
+
+import (
+	"io/fs"
+)
+
+type (
+	// Option configures how a service is instantiated.
+	Option func(*options)
+
+	options struct {
+		envPrefix      string
+		fsys           fs.FS
+		redactPatterns []string
+		searchPaths    []string
+		// section contains the dotted configuration section the service configuration is deserialized from; the root
+		// when empty.
+		section string
+	}
+)
+
+// WithEnvPrefix prefixes the environment variable(s) bound to configuration keys (e.g. WithEnvPrefix("webserver") binds
+// v1.test to WEBSERVER_V1_TEST); no prefix is applied by default.
+func WithEnvPrefix(prefix string) Option {
+	return func(o *options) {
+		o.envPrefix = prefix
+	}
+}
+
+// WithRedactPatterns redacts the value(s) of configuration keys matching the referenced pattern(s) (regular expressions)
+// wherever the configuration is printed; fields tagged `secret:"true"` are always redacted.
+func WithRedactPatterns(patterns ...string) Option {
+	return func(o *options) {
+		o.redactPatterns = append(o.redactPatterns, patterns...)
+	}
+}
+
+// WithConfigSection deserializes the service configuration from the referenced (dotted) section of the configuration
+// file(s) rather than the root (e.g. WithConfigSection("public") reads public.*), so the member(s) of a group never
+// share configuration keys; the saddle configuration within the section (e.g. public.saddle.*) takes precedence over
+// the shared saddle configuration. The member(s) of a Group default to the section of their name.
+func WithConfigSection(section string) Option {
+	return func(o *options) {
+		o.section = section
+	}
+}
+
+// WithFS merges the configuration file(s) within the root of the referenced file system (e.g. an embed.FS compiled into
+// the binary) beneath the configuration file(s) found within the search path(s); use fs.Sub to embed a folder.
+func WithFS(fsys fs.FS) Option {
+	return func(o *options) {
+		o.fsys = fsys
+	}
+}
+
+// WithSearchPaths replaces the ordered path(s) searched for configuration file(s) (e.g. /etc/<service>,
+// $HOME/.config/<service>, .config); environment variable(s) within a path are expanded, and each configuration file is
+// read from the first path containing it. Only .config is searched by default.
+func WithSearchPaths(paths ...string) Option {
+	return func(o *options) {
+		o.searchPaths = paths
+	}
+}
+
+// newOptions constructs the options of a service from the referenced option(s).
+func newOptions(opts ...Option) *options {
+	o := &options{
+		searchPaths: []string{configFolder},
+	}
+	for _, opt := range opts {
+		opt(o)
+	}
+	return o
+}
@@ -1,14 +1,24 @@
 package logger
 
-import "go.uber.org/zap"
+import (
+	"context"
+
+	"go.opentelemetry.io/otel/trace"
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
 
 type (
 	Environment string
 
+	// contextKey contains the key in which a logger is attached to a context (see NewContext).
+	contextKey struct{}
+
 	Logger struct {
 		env     Environment
 		log     *zap.Logger
 		options []zap.Option
+		secrets *secrets
 		service string
 	}
 )
@@ -22,6 +32,11 @@ const (
 )
 
 func New(env Environment, service string, options ...zap.Option) *Logger {
+	s := &secrets{}
+	options = append(options, zap.WrapCore(func(c zapcore.Core) zapcore.Core {
+		return &redactCore{Core: c, secrets: s}
+	}))
+
 	var l *zap.Logger
 
 	switch env {
@@ -34,6 +49,7 @@ func New(env Environment, service string, options ...zap.Option) *Logger {
 		env:     Environment(env),
 		log:     l,
 		options: options,
+		secrets: s,
 		service: service,
 	}
 }
@@ -55,6 +71,64 @@ func (l *Logger) SetEnvironment(env Environment, service string) {
 	}
 }
 
+// Redact masks the referenced secret value(s) wherever they appear within the message or field(s) of any subsequently
+// logged message.
+func (l *Logger) Redact(values ...string) {
+	l.secrets.add(values...)
+}
+
+// Redacted returns the referenced string with any secret value(s) masked.
+func (l *Logger) Redacted(v string) string {
+	return l.secrets.redact(v)
+}
+
+// WithContext returns a logger which attaches the trace and span identifiers of any span referenced by the context to
+// every logged message; the logger itself is returned when the context does not reference a valid span.
+func (l *Logger) WithContext(ctx context.Context) *Logger {
+	sc := trace.SpanContextFromContext(ctx)
+	if !sc.IsValid() {
+		return l
+	}
+	return &Logger{
+		env: l.env,
+		log: l.log.With(
+			zap.String("trace_id", sc.TraceID().String()),
+			zap.String("span_id", sc.SpanID().String()),
+		),
+		secrets: l.secrets,
+		service: l.service,
+	}
+}
+
+// With returns a logger which attaches the referenced field(s) to every logged message.
+func (l *Logger) With(fields ...zap.Field) *Logger {
+	return &Logger{
+		env:     l.env,
+		log:     l.log.With(fields...),
+		secrets: l.secrets,
+		service: l.service,
+	}
+}
+
+// NewContext returns a copy of the referenced context carrying the referenced logger (see FromContext).
+func NewContext(ctx context.Context, l *Logger) context.Context {
+	return context.WithValue(ctx, contextKey{}, l)
+}
+
+// FromContext returns the logger carried by the referenced context; within a request handled by saddle this is the
+// request-scoped logger, which attaches the request ID and the trace and span identifiers of the request to every
+// logged message. A no-op logger is returned when the context carries none.
+func FromContext(ctx context.Context) *Logger {
+	if l, ok := ctx.Value(contextKey{}).(*Logger); ok {
+		return l
+	}
+	return &Logger{
+		env:     Unknown,
+		log:     zap.NewNop(),
+		secrets: &secrets{},
+	}
+}
+
 func (l *Logger) Sync() {
 	l.log.Sync()
 }
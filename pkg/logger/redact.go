@@ -0,0 +1,93 @@
+package logger
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"go.uber.org/zap"
+	"go.uber.org/zap/zapcore"
+)
+
+// redactedValue contains the mask substituted for secret value(s).
+const redactedValue = "******"
+
+type (
+	// secrets contains the secret value(s) masked within logged message(s) and field(s).
+	secrets struct {
+		mu     sync.RWMutex
+		values []string
+		// seen contains the secret value(s) added; value(s) are re-added on every configuration reload.
+		seen map[string]bool
+	}
+
+	// redactCore wraps a zap core, masking secret value(s) within the message and string | error field(s) of every
+	// logged entry.
+	redactCore struct {
+		zapcore.Core
+		secrets *secrets
+	}
+)
+
+// add adds the referenced secret value(s); empty | already added value(s) are ignored.
+func (s *secrets) add(values ...string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.seen == nil {
+		s.seen = map[string]bool{}
+	}
+	for _, v := range values {
+		if v != "" && !s.seen[v] {
+			s.seen[v] = true
+			s.values = append(s.values, v)
+		}
+	}
+}
+
+// redact masks any secret value(s) within the referenced string.
+func (s *secrets) redact(v string) string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	for _, sv := range s.values {
+		v = strings.ReplaceAll(v, sv, redactedValue)
+	}
+	return v
+}
+
+func (c *redactCore) With(fields []zapcore.Field) zapcore.Core {
+	return &redactCore{
+		Core:    c.Core.With(c.redactFields(fields)),
+		secrets: c.secrets,
+	}
+}
+
+func (c *redactCore) Check(e zapcore.Entry, ce *zapcore.CheckedEntry) *zapcore.CheckedEntry {
+	if c.Enabled(e.Level) {
+		return ce.AddCore(e, c)
+	}
+	return ce
+}
+
+func (c *redactCore) Write(e zapcore.Entry, fields []zapcore.Field) error {
+	e.Message = c.secrets.redact(e.Message)
+	return c.Core.Write(e, c.redactFields(fields))
+}
+
+// redactFields returns a copy of the referenced field(s) with any secret value(s) masked.
+func (c *redactCore) redactFields(fields []zapcore.Field) []zapcore.Field {
+	redacted := make([]zapcore.Field, len(fields))
+	for i, f := range fields {
+		switch f.Type {
+		case zapcore.StringType:
+			f.String = c.secrets.redact(f.String)
+		case zapcore.ErrorType, zapcore.StringerType:
+			if s := fmt.Sprint(f.Interface); c.secrets.redact(s) != s {
+				f = zap.String(f.Key, c.secrets.redact(s))
+			}
+		}
+		redacted[i] = f
+	}
+	return redacted
+}
@@ -0,0 +1,22 @@
+package logger
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestSecretsAdd(t *testing.T) {
+	s := &secrets{}
+	// every configuration reload re-adds the secret value(s) it resolved
+	for i := 0; i < 3; i++ {
+		s.add("hunter2", "", "s3cr3t")
+	}
+	s.add("rotated", "hunter2")
+
+	if want := []string{"hunter2", "s3cr3t", "rotated"}; !reflect.DeepEqual(s.values, want) {
+		t.Errorf("values = %q; want %q", s.values, want)
+	}
+	if got, want := s.redact("token hunter2 | rotated"), "token ****** | ******"; got != want {
+		t.Errorf("redact = %q; want %q", got, want)
+	}
+}
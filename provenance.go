@@ -0,0 +1,73 @@
+package saddle
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+type (
+	// SourceKind contains the kind of source a configuration value was loaded from.
+	SourceKind string
+
+	// Source contains the source a configuration value was loaded from.
+	Source struct {
+		// Kind contains the kind of the source.
+		Kind SourceKind
+		// Name contains the file path, environment variable or flag name of the source.
+		Name string
+	}
+
+	// Provenance contains the source of each loaded configuration key; keys are dotted mapstructure keys (e.g.
+	// saddle.jaeger.uri).
+	Provenance map[string]Source
+)
+
+const (
+	// SourceStructDefault identifies values loaded from the `default:"..."` struct tag of a configuration field.
+	SourceStructDefault SourceKind = "struct default"
+	// SourceEmbeddedFile identifies values loaded from a configuration file embedded within the binary (see WithFS).
+	SourceEmbeddedFile SourceKind = "embedded file"
+	// SourceDefaultFile identifies values loaded from the default configuration file (<search path>/default.*).
+	SourceDefaultFile SourceKind = "default file"
+	// SourceEnvironmentFile identifies values loaded from the environment configuration file (<search path>/<env>.*).
+	SourceEnvironmentFile SourceKind = "environment file"
+	// SourceLocalFile identifies values loaded from the local override configuration file
+	// (<search path>/<env>.local.*).
+	SourceLocalFile SourceKind = "local file"
+	// SourceConfigFile identifies values loaded from the configuration file referenced by the --config flag.
+	SourceConfigFile SourceKind = "config file"
+	// SourceEnvVar identifies values loaded from an environment variable.
+	SourceEnvVar SourceKind = "environment variable"
+	// SourceFlag identifies values loaded from a command-line flag.
+	SourceFlag SourceKind = "flag"
+)
+
+// String returns the kind and name of the source (e.g. environment file (.config/local.yaml)).
+func (s Source) String() string {
+	return fmt.Sprintf("%s (%s)", s.Kind, s.Name)
+}
+
+// Lookup returns the source of the referenced configuration key.
+func (p Provenance) Lookup(key string) (Source, bool) {
+	s, ok := p[strings.ToLower(key)]
+	return s, ok
+}
+
+// Keys returns the sorted configuration keys with a recorded source.
+func (p Provenance) Keys() []string {
+	keys := make([]string, 0, len(p))
+	for k := range p {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// record records the referenced source for each of the referenced configuration keys; any previously recorded source
+// of a key is overridden as later sources take precedence.
+func (p Provenance) record(source Source, keys ...string) {
+	for _, k := range keys {
+		p[k] = source
+	}
+}
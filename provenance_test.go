@@ -0,0 +1,160 @@
+package saddle
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"testing/fstest"
+
+	"github.com/spf13/pflag"
+)
+
+func TestLoadLayers(t *testing.T) {
+	tests := []struct {
+		name     string
+		embedded map[string]string
+		disk     map[string]string
+		file     string
+		env      map[string]string
+		flag     string
+		want     string
+		source   SourceKind
+	}{
+		{
+			name:     "embedded environment file",
+			embedded: map[string]string{"default.yaml": "name: embedded-default", "test.yaml": "name: embedded-test"},
+			want:     "embedded-test",
+			source:   SourceEmbeddedFile,
+		},
+		{
+			name:     "default file above embedded file(s)",
+			embedded: map[string]string{"test.yaml": "name: embedded-test"},
+			disk:     map[string]string{"default.yaml": "name: default"},
+			want:     "default",
+			source:   SourceDefaultFile,
+		},
+		{
+			name:   "environment file above default file",
+			disk:   map[string]string{"default.yaml": "name: default", "test.yaml": "name: test"},
+			want:   "test",
+			source: SourceEnvironmentFile,
+		},
+		{
+			name:   "local file above environment file",
+			disk:   map[string]string{"test.yaml": "name: test", "test.local.yaml": "name: local"},
+			want:   "local",
+			source: SourceLocalFile,
+		},
+		{
+			name:   "config file above local file",
+			disk:   map[string]string{"test.yaml": "name: test", "test.local.yaml": "name: local"},
+			file:   "explicit.yaml",
+			want:   "explicit",
+			source: SourceConfigFile,
+		},
+		{
+			name:   "environment variable above config file",
+			disk:   map[string]string{"test.yaml": "name: test"},
+			file:   "explicit.yaml",
+			env:    map[string]string{"NAME": "env"},
+			want:   "env",
+			source: SourceEnvVar,
+		},
+		{
+			name:   "flag above environment variable",
+			disk:   map[string]string{"test.yaml": "name: test"},
+			env:    map[string]string{"NAME": "env"},
+			flag:   "flag",
+			want:   "flag",
+			source: SourceFlag,
+		},
+		{
+			name:   "value of another format",
+			disk:   map[string]string{"test.json": `{"name": "json"}`},
+			want:   "json",
+			source: SourceEnvironmentFile,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			dir := t.TempDir()
+			for name, data := range tt.disk {
+				if err := os.WriteFile(filepath.Join(dir, name), []byte(data), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			for k, v := range tt.env {
+				t.Setenv(k, v)
+			}
+
+			opts := []Option{WithSearchPaths(dir)}
+			if tt.embedded != nil {
+				fsys := fstest.MapFS{}
+				for name, data := range tt.embedded {
+					fsys[name] = &fstest.MapFile{Data: []byte(data)}
+				}
+				opts = append(opts, WithFS(fsys))
+			}
+			o := newOptions(opts...)
+
+			l := &loader{
+				environment: "test",
+				options:     o,
+				paths:       o.searchPaths,
+				service:     "svc",
+			}
+			if tt.file != "" {
+				l.file = filepath.Join(t.TempDir(), tt.file)
+				if err := os.WriteFile(l.file, []byte("name: explicit"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+			}
+			if tt.flag != "" {
+				l.flags = pflag.NewFlagSet("svc", pflag.ContinueOnError)
+				if err := defineFlag(l.flags, &testConfig{}, &serviceFlag{key: "name", name: "name"}); err != nil {
+					t.Fatal(err)
+				}
+				if err := l.flags.Parse([]string{"--name", tt.flag}); err != nil {
+					t.Fatal(err)
+				}
+			}
+
+			cfg := &testConfig{}
+			ld, err := l.load(cfg)
+			if err != nil {
+				t.Fatalf("load: %v", err)
+			}
+			if cfg.Name != tt.want {
+				t.Errorf("name = %q; want %q", cfg.Name, tt.want)
+			}
+			if s, _ := ld.provenance.Lookup("name"); s.Kind != tt.source {
+				t.Errorf("source = %s; want %s", s, tt.source)
+			}
+		})
+	}
+}
+
+func TestProvenanceRecord(t *testing.T) {
+	pv := Provenance{}
+	pv.record(Source{Kind: SourceDefaultFile, Name: "default.yaml"}, "a", "b")
+	pv.record(Source{Kind: SourceEnvironmentFile, Name: "test.yaml"}, "b")
+
+	tests := []struct {
+		key  string
+		want SourceKind
+		ok   bool
+	}{
+		{key: "a", want: SourceDefaultFile, ok: true},
+		{key: "B", want: SourceEnvironmentFile, ok: true},
+		{key: "c"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.key, func(t *testing.T) {
+			s, ok := pv.Lookup(tt.key)
+			if ok != tt.ok || s.Kind != tt.want {
+				t.Errorf("Lookup(%q) = %v, %t; want %q, %t", tt.key, s, ok, tt.want, tt.ok)
+			}
+		})
+	}
+}
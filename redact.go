@@ -0,0 +1,116 @@
+package saddle
+
+import (
+	"reflect"
+	"regexp"
+	"strings"
+)
+
+const (
+	// redactedValue contains the mask substituted for the value of a redacted configuration key.
+	redactedValue = "******"
+	// secretTag contains the struct tag marking a configuration field as secret (e.g. `secret:"true"`).
+	secretTag = "secret"
+)
+
+// defaultRedactPatterns contains the pattern(s) of configuration keys which are always redacted.
+var defaultRedactPatterns = []string{
+	`(?i)(password|passwd|secret|token|credential|api_key|private_key)`,
+}
+
+type (
+	// redactor masks the value(s) of secret configuration keys.
+	redactor struct {
+		keys     []string
+		patterns []*regexp.Regexp
+	}
+)
+
+// newRedactor constructs a new redactor of the referenced secret key(s) and key pattern(s).
+func newRedactor(keys []string, patterns ...string) (*redactor, error) {
+	r := &redactor{keys: keys}
+	for _, p := range append(append([]string{}, defaultRedactPatterns...), patterns...) {
+		re, err := regexp.Compile(p)
+		if err != nil {
+			return nil, err
+		}
+		r.patterns = append(r.patterns, re)
+	}
+	return r, nil
+}
+
+// redacted reports whether the value of the referenced configuration key (or any of its parent keys) is secret.
+func (r *redactor) redacted(key string) bool {
+	for _, k := range r.keys {
+		if key == k || strings.HasPrefix(key, k+".") {
+			return true
+		}
+	}
+	for _, re := range r.patterns {
+		if re.MatchString(key) {
+			return true
+		}
+	}
+	return false
+}
+
+// redact masks the secret value(s) of the referenced (nested) settings in place.
+func (r *redactor) redact(settings map[string]any, prefix string) {
+	for k, v := range settings {
+		key := joinKey(prefix, k)
+		if r.redacted(key) {
+			settings[k] = redactedValue
+			continue
+		}
+		if m, ok := v.(map[string]any); ok {
+			r.redact(m, key)
+		}
+	}
+}
+
+// secretKeys returns the dotted mapstructure keys of all fields tagged `secret:"true"` within the referenced
+// configuration(s).
+func secretKeys(configs ...any) []string {
+	var keys []string
+	for _, c := range configs {
+		keys = append(keys, structSecretKeys(reflect.TypeOf(c), "")...)
+	}
+	return keys
+}
+
+// structSecretKeys returns the dotted mapstructure keys of all secret fields within the referenced struct type.
+func structSecretKeys(t reflect.Type, prefix string) []string {
+	for t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil
+	}
+
+	var keys []string
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() {
+			continue
+		}
+
+		key := prefix
+		if !strings.Contains(f.Tag.Get("mapstructure"), ",squash") {
+			key = joinKey(prefix, mapstructureName(f))
+		}
+		if f.Tag.Get(secretTag) == "true" {
+			keys = append(keys, key)
+			continue
+		}
+		keys = append(keys, structSecretKeys(f.Type, key)...)
+	}
+	return keys
+}
+
+// joinKey joins the referenced parent and child configuration keys.
+func joinKey(parent, child string) string {
+	if parent == "" {
+		return child
+	}
+	return parent + "." + child
+}
@@ -0,0 +1,204 @@
+package saddle
+
+import (
+	"errors"
+	"fmt"
+	"io/fs"
+	"maps"
+	"os"
+	"os/signal"
+	"path/filepath"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/spf13/viper"
+	"go.uber.org/zap"
+
+	"github.com/captjt/saddle/models"
+	log "github.com/captjt/saddle/pkg/logger"
+)
+
+const (
+	// reloadDebounce contains the duration to wait for file event(s) to settle before reloading; editors and config
+	// map updates (an atomic swap of the ..data symlink of the mounted folder) often emit several events for a single
+	// change.
+	reloadDebounce = 250 * time.Millisecond
+
+	reloadTriggerFile   = "file"
+	reloadTriggerSignal = "signal"
+
+	reloadOutcomeAccepted = "accepted"
+	reloadOutcomeRejected = "rejected"
+)
+
+// watch monitors the configuration file(s) of the referenced environment and SIGHUP, reloading the configuration of
+// the (Reloadable) service on change; configuration file(s) which are symlinks (e.g. mounted from a Kubernetes config
+// map) are re-resolved on every event within their folder, so a swapped symlink target is a change. Embedded
+// configuration file(s) cannot change and are not watched. The watch ends once the project stops.
+func (p *Project[T]) watch(logger *log.Logger) error {
+	w, err := fsnotify.NewWatcher()
+	if err != nil {
+		return err
+	}
+
+	// - watch existing search path(s) and the folder of the explicit configuration file ↴
+	dirs := p.loader.paths
+	if p.loader.file != "" {
+		dirs = append(dirs[:len(dirs):len(dirs)], filepath.Dir(p.loader.file))
+	}
+	var watched int
+	for _, d := range dirs {
+		if err := w.Add(d); err != nil {
+			if errors.Is(err, fs.ErrNotExist) {
+				continue
+			}
+			w.Close()
+			return err
+		}
+		watched++
+	}
+	if watched == 0 {
+		w.Close()
+		return fmt.Errorf("none of the configuration path(s) exist: %s", strings.Join(dirs, ", "))
+	}
+
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+
+	// - end the watch once the project stops; any in-flight reload finishes first ↴
+	done, stopped := make(chan struct{}), make(chan struct{})
+	p.unwatch = func() {
+		signal.Stop(hup)
+		close(done)
+		<-stopped
+		w.Close()
+	}
+
+	resolved := p.loader.resolveLayers(dirs)
+	go func() {
+		defer close(stopped)
+
+		var debounce <-chan time.Time
+		for {
+			select {
+			case <-done:
+				return
+			case e, ok := <-w.Events:
+				if !ok {
+					return
+				}
+				if r := p.loader.resolveLayers(dirs); p.loader.isLayer(e.Name) || !maps.Equal(r, resolved) {
+					resolved = r
+					debounce = time.After(reloadDebounce)
+				}
+			case err, ok := <-w.Errors:
+				if !ok {
+					return
+				}
+				logger.Error("configuration watcher error",
+					zap.Error(err),
+				)
+			case <-debounce:
+				debounce = nil
+				p.reload(logger, reloadTriggerFile)
+			case <-hup:
+				p.reload(logger, reloadTriggerSignal)
+			}
+		}
+	}()
+	return nil
+}
+
+// reload loads | validates a candidate configuration and hands it to the (Reloadable) service; a rejected candidate is
+// logged and the current configuration is kept.
+func (p *Project[T]) reload(logger *log.Logger, trigger string) {
+	r, ok := any(p.service).(Reloadable)
+	if !ok {
+		return
+	}
+
+	var ld *loaded
+	candidate, err := newConfig(p.service)
+	if err == nil {
+		if ld, err = p.loader.load(candidate); err == nil {
+			err = r.Reload(candidate)
+		}
+	}
+
+	if ld != nil {
+		logger.Redact(secretValues(ld.secrets)...)
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.reloads.LastAttemptAt = time.Now().UTC().Format(time.RFC3339)
+	p.reloads.LastTrigger = trigger
+	if err != nil {
+		p.reloads.Rejected++
+		p.reloads.LastOutcome = reloadOutcomeRejected
+		p.reloads.LastError = logger.Redacted(err.Error())
+		logger.Error("configuration reload rejected; keeping current configuration",
+			zap.String("trigger", trigger),
+			zap.Error(err),
+		)
+		return
+	}
+
+	p.loaded = ld
+	p.reloads.Accepted++
+	p.reloads.LastOutcome = reloadOutcomeAccepted
+	p.reloads.LastError = ""
+	logger.Info("configuration reloaded",
+		zap.String("trigger", trigger),
+	)
+}
+
+// reloadStatus returns a copy of the outcome(s) of configuration reload(s).
+func (p *Project[T]) reloadStatus() *models.ReloadStatus {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	rs := p.reloads
+	return &rs
+}
+
+// resolveLayers returns the resolved path (i.e. symlink target) of each existing configuration file merged for the
+// environment within the referenced folder(s), keyed by path.
+func (l *loader) resolveLayers(dirs []string) map[string]string {
+	var paths []string
+	for _, d := range dirs {
+		for _, name := range []string{defaultConfigName, l.environment, l.environment + localConfigSuffix} {
+			for _, ext := range viper.SupportedExts {
+				paths = append(paths, filepath.Join(d, fmt.Sprintf("%s.%s", name, ext)))
+			}
+		}
+	}
+	if l.file != "" {
+		paths = append(paths, l.file)
+	}
+
+	resolved := map[string]string{}
+	for _, p := range paths {
+		if r, err := filepath.EvalSymlinks(p); err == nil {
+			resolved[p] = r
+		}
+	}
+	return resolved
+}
+
+// isLayer reports whether the referenced file is one of the configuration file(s) merged for the environment.
+func (l *loader) isLayer(path string) bool {
+	if l.file != "" && filepath.Clean(path) == filepath.Clean(l.file) {
+		return true
+	}
+
+	base := filepath.Base(path)
+	switch strings.TrimSuffix(base, filepath.Ext(base)) {
+	case defaultConfigName, l.environment, l.environment + localConfigSuffix:
+		return true
+	}
+	return false
+}
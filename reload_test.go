@@ -0,0 +1,184 @@
+package saddle
+
+import (
+	"os"
+	"os/signal"
+	"path/filepath"
+	"syscall"
+	"testing"
+	"time"
+
+	log "github.com/captjt/saddle/pkg/logger"
+)
+
+type (
+	// reloadService contains a Reloadable service handing each reloaded configuration to a channel.
+	reloadService struct {
+		testService
+		reloads chan *testConfig
+	}
+)
+
+func (s *reloadService) Reload(config any) error {
+	s.reloads <- config.(*testConfig)
+	return nil
+}
+
+// watchProject constructs a project of a Reloadable service watching the configuration file(s) of the referenced
+// folder.
+func watchProject(t *testing.T, dir string) (*Project[*reloadService], *reloadService) {
+	t.Helper()
+
+	o := newOptions(WithSearchPaths(dir))
+	l := &loader{
+		environment: "test",
+		options:     o,
+		paths:       o.searchPaths,
+		service:     "svc",
+	}
+	ld, err := l.load(&testConfig{})
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	svc := &reloadService{reloads: make(chan *testConfig, 8)}
+	p := &Project[*reloadService]{
+		loaded:  ld,
+		loader:  l,
+		logger:  log.New(log.Production, "svc"),
+		service: svc,
+	}
+	if err := p.watch(p.logger); err != nil {
+		t.Fatalf("watch: %v", err)
+	}
+	return p, svc
+}
+
+// symlink replaces the referenced symlink atomically, as a Kubernetes config map update does.
+func symlink(t *testing.T, target, link string) {
+	t.Helper()
+
+	tmp := link + "_tmp"
+	if err := os.Symlink(target, tmp); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.Rename(tmp, link); err != nil {
+		t.Fatal(err)
+	}
+}
+
+// writeConfig writes a configuration file naming the service within the referenced folder.
+func writeConfig(t *testing.T, dir, file, name string) {
+	t.Helper()
+
+	if err := os.MkdirAll(dir, 0o700); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(filepath.Join(dir, file), []byte("name: "+name), 0o600); err != nil {
+		t.Fatal(err)
+	}
+}
+
+func TestWatchConfigMapUpdate(t *testing.T) {
+	// - lay out the folder as mounted from a config map: test.yaml ⇢ ..data/test.yaml ⇢ ..v1/test.yaml ↴
+	dir := t.TempDir()
+	writeConfig(t, filepath.Join(dir, "..v1"), "test.yaml", "v1")
+	symlink(t, "..v1", filepath.Join(dir, "..data"))
+	if err := os.Symlink(filepath.Join("..data", "test.yaml"), filepath.Join(dir, "test.yaml")); err != nil {
+		t.Fatal(err)
+	}
+
+	p, svc := watchProject(t, dir)
+	defer p.unwatch()
+
+	// - update the config map; only the ..data symlink changes ↴
+	writeConfig(t, filepath.Join(dir, "..v2"), "test.yaml", "v2")
+	symlink(t, "..v2", filepath.Join(dir, "..data"))
+
+	select {
+	case c := <-svc.reloads:
+		if c.Name != "v2" {
+			t.Errorf("reloaded name = %q; want v2", c.Name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("config map update did not reload the configuration")
+	}
+}
+
+func TestWatchFileChange(t *testing.T) {
+	dir := t.TempDir()
+	writeConfig(t, dir, "test.yaml", "v1")
+
+	p, svc := watchProject(t, dir)
+	defer p.unwatch()
+
+	// - an unrelated file is not a change ↴
+	writeConfig(t, dir, "other.yaml", "other")
+	select {
+	case c := <-svc.reloads:
+		t.Fatalf("unrelated file reloaded the configuration: %+v", c)
+	case <-time.After(3 * reloadDebounce):
+	}
+
+	writeConfig(t, dir, "test.yaml", "v2")
+	select {
+	case c := <-svc.reloads:
+		if c.Name != "v2" {
+			t.Errorf("reloaded name = %q; want v2", c.Name)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("file change did not reload the configuration")
+	}
+}
+
+func TestWatchStopped(t *testing.T) {
+	// SIGHUP terminates the process unless notified; keep notifying once the watch ended
+	hup := make(chan os.Signal, 1)
+	signal.Notify(hup, syscall.SIGHUP)
+	defer signal.Stop(hup)
+
+	dir := t.TempDir()
+	writeConfig(t, dir, "test.yaml", "v1")
+
+	p, svc := watchProject(t, dir)
+	p.unwatch()
+
+	// - neither a file change nor SIGHUP reloads a stopped project ↴
+	writeConfig(t, dir, "test.yaml", "v2")
+	proc, err := os.FindProcess(os.Getpid())
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := proc.Signal(syscall.SIGHUP); err != nil {
+		t.Fatal(err)
+	}
+
+	select {
+	case c := <-svc.reloads:
+		t.Fatalf("stopped project reloaded the configuration: %+v", c)
+	case <-time.After(3 * reloadDebounce):
+	}
+}
+
+func TestIsLayer(t *testing.T) {
+	l := &loader{environment: "test", file: "/etc/svc/explicit.yaml"}
+
+	tests := []struct {
+		path string
+		want bool
+	}{
+		{path: ".config/default.yaml", want: true},
+		{path: ".config/test.json", want: true},
+		{path: ".config/test.local.yaml", want: true},
+		{path: "/etc/svc/explicit.yaml", want: true},
+		{path: ".config/stg.yaml"},
+		{path: ".config/..data"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.path, func(t *testing.T) {
+			if got := l.isLayer(tt.path); got != tt.want {
+				t.Errorf("isLayer(%q) = %t; want %t", tt.path, got, tt.want)
+			}
+		})
+	}
+}
@@ -2,21 +2,15 @@ package saddle
 
 import (
 	"fmt"
-	"strings"
 
 	"github.com/common-nighthawk/go-figure"
-	"github.com/go-playground/validator/v10"
 	"github.com/spf13/cobra"
-	"github.com/spf13/viper"
 	"go.uber.org/zap"
 
-	"github.com/captjt/saddle/models"
 	log "github.com/captjt/saddle/pkg/logger"
 )
 
 const (
-	configFolder = ".config"
-
 	logoText = "saddle"
 )
 
@@ -28,104 +22,49 @@ func init() {
 }
 
 func New(version string) *cobra.Command {
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:     "saddle",
 		Long:    "saddle up!",
 		Version: version,
 	}
+	cmd.AddCommand(configCommand())
+	return cmd
 }
 
-func config[T Service](service T, environment string) *models.Config {
-	viper.SetEnvKeyReplacer(strings.NewReplacer(".", "_"))
-	viper.AutomaticEnv()
-	// viper.SetEnvPrefix(service.Name())
-
-	// - handle import of any configuration file; set by referenced environment ↴
-	viper.SetConfigName(environment)
-	viper.AddConfigPath(configFolder)
-	if err := viper.ReadInConfig(); err != nil {
-		if _, ok := err.(viper.ConfigFileNotFoundError); ok {
-			logger.Fatal("config file not found",
-				zap.String("config folder", configFolder),
-				zap.String("environment", environment),
-			)
-		} else {
-			logger.Fatal("config file error",
-				zap.Error(err),
-			)
-		}
-	}
-
-	v := validator.New()
-
-	// - deserialize | validate saddle configuration(s) ↴
-	hc := &models.Config{}
-	if err := viper.Unmarshal(hc); err != nil {
-		logger.Fatal("saddle config deserialization error",
-			zap.Error(err),
-		)
-	}
-	if err := v.Struct(hc); err != nil {
-		logger.Fatal("saddle config validation error",
-			zap.Error(err),
-		)
-	}
-
-	// - deserialize | validate service configuration(s) ↴
-	sc := service.Config()
-	if err := viper.Unmarshal(sc); err != nil {
-		logger.Fatal("service config deserialization error",
-			zap.Error(err),
-		)
-	}
-	if err := v.Struct(sc); err != nil {
-		logger.Fatal("service config validation error",
-			zap.Error(err),
-		)
-	}
-
-	return hc
-}
-
-func Command[T Service](service T, entry func(*cobra.Command, []string) error) *cobra.Command {
+// Command constructs the command of a service with the standard flag(s) registered: --environment and --address
+// (seeded from the ENVIRONMENT | ADDRESS environment variable(s) and required), --config and --config-path. Service
+// specific flag(s) are registered by the referenced option(s) (see WithFlag).
+func Command[T Service](service T, entry func(*cobra.Command, []string) error, opts ...CommandOption) *cobra.Command {
 	sn := service.Name()
-	return &cobra.Command{
+	cmd := &cobra.Command{
 		Use:   sn,
 		Short: fmt.Sprintf("%s service", sn),
 		RunE:  entry,
 	}
-}
-
-func Instantiate[T Service](service T) (T, func(cmd *cobra.Command, args []string) error) {
-	return service, func(cmd *cobra.Command, args []string) error {
-		env, address := viper.GetString(fmt.Sprintf("%s.%s", service.Name(), "environment")),
-			viper.GetString(fmt.Sprintf("%s.%s", service.Name(), "address"))
-		// Have to call config() here to ensure the environment is set before the logger is updated.
-		_ = config(service, env)
-		// display project logo w/ service name, environment and description
-		logo.Print()
-		fmt.Printf("\n%s [%s]\n   ⤷ %s\n\n", service.Name(), env, service.Description())
-
-		// update logger for proper env and service
-		logger.SetEnvironment(log.Environment(env), service.Name())
-
-		var (
-			err error
-		)
-
-		// - instantiate new service ↴
-		s, err := new(service, logger, service.Validator())
-		if err != nil {
-			logger.Fatal("unable to attach service",
-				zap.String("service", service.Name()),
+	pf := standardFlags(cmd)
+	pf.StringP(addressFlag, "a", "", addressUsage)
+	seedFlag(cmd, addressFlag)
+
+	// - define service specific command-line parameters; bound to the service configuration ↴
+	o := newCommandOptions(opts...)
+	registerFlags(sn, o.flags)
+	for _, sf := range o.flags {
+		if err := defineFlag(pf, service.Config(), sf); err != nil {
+			logger.Fatal("unable to define service flag",
+				zap.String("service", sn),
+				zap.String("flag", sf.name),
 				zap.Error(err),
 			)
 		}
+	}
+	return cmd
+}
 
-		// - execute | expose service ↴
-		logger.Info("listening for requests",
-			zap.String("address", address),
-		)
-		return s.App.Listen(address)
+// Instantiate joins the referenced service (see Join) and returns the entry of its command; the entry runs the
+// service until it stops or the process receives SIGINT | SIGTERM.
+func Instantiate[T Service](service T, opts ...Option) (T, func(cmd *cobra.Command, args []string) error) {
+	m := Join(service, opts...)
+	return service, func(cmd *cobra.Command, args []string) error {
+		return RunAll(cmd, m)
 	}
 }
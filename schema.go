@@ -0,0 +1,375 @@
+package saddle
+
+import (
+	"fmt"
+	"os"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/captjt/saddle/models"
+)
+
+// schemaDialect contains the JSON Schema dialect of generated schema(s).
+const schemaDialect = "https://json-schema.org/draft/2020-12/schema"
+
+// fileModePattern contains the pattern of an octal file mode string (e.g. 0660 | 0o660).
+const fileModePattern = `^0([oO]?[0-7]{1,3})?$`
+
+// durationPattern contains the pattern of a Go duration string (e.g. 1m30s); the unitless 0 is a duration too.
+const durationPattern = `^(0|([0-9]+(\.[0-9]+)?(ns|us|µs|ms|s|m|h))+)$`
+
+type (
+	// Schema contains a JSON Schema document.
+	Schema map[string]any
+)
+
+var (
+	durationType = reflect.TypeOf(time.Duration(0))
+	fileModeType = reflect.TypeOf(os.FileMode(0))
+)
+
+// NewSchema generates the JSON Schema document of the saddle and service configuration(s) by reflecting over
+// models.Config and the value returned by service.Config(). Properties are named by their mapstructure tags, and
+// validate tags are translated where JSON Schema has an equivalent: required ⇢ required (unless defaulted),
+// min | max ⇢ minimum | maximum (or the length | item equivalent), oneof ⇢ enum, uri ⇢ format, required_with ⇢
+// dependentRequired and excluded_with ⇢ not (of both values being non-zero). Default struct tags are translated to
+// default.
+func NewSchema(service Service) (Schema, error) {
+	s := Schema{
+		"$schema": schemaDialect,
+		"title":   fmt.Sprintf("%s configuration", service.Name()),
+		"type":    "object",
+	}
+
+	for _, c := range []any{&models.Config{}, service.Config()} {
+		cs, err := structSchema(reflect.TypeOf(c), false)
+		if err != nil {
+			return nil, err
+		}
+		mergeSchema(s, cs)
+	}
+	return s, nil
+}
+
+// mergeSchema merges the properties and constraints of the referenced object schema into the destination schema.
+func mergeSchema(dst, src map[string]any) {
+	for _, k := range []string{"properties", "dependentRequired"} {
+		if sm, ok := src[k].(map[string]any); ok {
+			dm, _ := dst[k].(map[string]any)
+			if dm == nil {
+				dm = map[string]any{}
+				dst[k] = dm
+			}
+			for pk, pv := range sm {
+				dm[pk] = pv
+			}
+		}
+	}
+	for _, k := range []string{"required", "allOf"} {
+		switch sv := src[k].(type) {
+		case []string:
+			dv, _ := dst[k].([]string)
+			dst[k] = append(dv, sv...)
+		case []any:
+			dv, _ := dst[k].([]any)
+			dst[k] = append(dv, sv...)
+		}
+	}
+}
+
+// typeSchema returns the schema of the referenced type.
+func typeSchema(t reflect.Type) (map[string]any, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+
+	if t == durationType {
+		// durations decode from a duration string or an integer number of nanoseconds
+		return map[string]any{"type": []string{"string", "integer"}, "pattern": durationPattern}, nil
+	}
+	if t == fileModeType {
+		// file modes decode from an octal string or an integer (an unquoted YAML octal literal, e.g. 0660)
+		return map[string]any{
+			"type":    []string{"string", "integer"},
+			"pattern": fileModePattern,
+			"minimum": 0,
+			"maximum": int(os.ModePerm),
+		}, nil
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		return map[string]any{"type": "boolean"}, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return map[string]any{"type": "integer"}, nil
+	case reflect.Float32, reflect.Float64:
+		return map[string]any{"type": "number"}, nil
+	case reflect.String:
+		return map[string]any{"type": "string"}, nil
+	case reflect.Slice, reflect.Array:
+		items, err := typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "array", "items": items}, nil
+	case reflect.Map:
+		values, err := typeSchema(t.Elem())
+		if err != nil {
+			return nil, err
+		}
+		return map[string]any{"type": "object", "additionalProperties": values}, nil
+	case reflect.Struct:
+		return structSchema(t, true)
+	case reflect.Interface:
+		return map[string]any{}, nil
+	}
+	return nil, fmt.Errorf("unsupported configuration type: %s", t)
+}
+
+// structSchema returns the object schema of the referenced struct type; strict schemas reject unknown properties so
+// typos within configuration file(s) are caught.
+func structSchema(t reflect.Type, strict bool) (map[string]any, error) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("configuration must be a struct; got %s", t)
+	}
+
+	s := map[string]any{
+		"type":       "object",
+		"properties": map[string]any{},
+	}
+	if strict {
+		s["additionalProperties"] = false
+	}
+
+	// - validate tags reference sibling fields by Go name; resolve their mapstructure keys ↴
+	keys, types := map[string]string{}, map[string]reflect.Type{}
+	for i := 0; i < t.NumField(); i++ {
+		keys[t.Field(i).Name] = mapstructureName(t.Field(i))
+		types[t.Field(i).Name] = t.Field(i).Type
+	}
+
+	var (
+		required  []string
+		allOf     []any
+		excluded  = map[string]bool{}
+		dependent = map[string]any{}
+	)
+	for i := 0; i < t.NumField(); i++ {
+		f := t.Field(i)
+		if !f.IsExported() || f.Tag.Get("mapstructure") == "-" {
+			continue
+		}
+
+		// - squashed struct(s) contribute their properties to the parent ↴
+		if strings.Contains(f.Tag.Get("mapstructure"), ",squash") {
+			ss, err := structSchema(f.Type, strict)
+			if err != nil {
+				return nil, err
+			}
+			mergeSchema(s, ss)
+			continue
+		}
+
+		key := keys[f.Name]
+		fs, err := typeSchema(f.Type)
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", key, err)
+		}
+
+		if d, ok := f.Tag.Lookup(defaultTag); ok {
+			fs["default"] = typedValue(f.Type, d)
+		}
+
+	rules:
+		for _, rule := range strings.Split(f.Tag.Get("validate"), ",") {
+			tag, param, _ := strings.Cut(rule, "=")
+			switch tag {
+			case "dive":
+				// rule(s) beyond dive apply to element(s) rather than the field
+				break rules
+			case "required":
+				// a default satisfies required, so the field may be left out of the configuration file(s)
+				if !defaulted(f) {
+					required = append(required, key)
+				}
+			case "min", "max", "len", "gt", "gte", "lt", "lte":
+				boundSchema(fs, f.Type, tag, param)
+			case "oneof":
+				fs["enum"] = enumValues(f.Type, strings.Fields(param))
+			case "uri", "url":
+				fs["format"] = "uri"
+			case "email", "hostname", "ipv4", "ipv6", "uuid":
+				fs["format"] = tag
+			case "required_with":
+				for _, n := range strings.Fields(param) {
+					if k, ok := keys[n]; ok {
+						d, _ := dependent[k].([]string)
+						dependent[k] = append(d, key)
+					}
+				}
+			case "excluded_with":
+				for _, n := range strings.Fields(param) {
+					k, ok := keys[n]
+					if !ok {
+						continue
+					}
+					pair := []string{key, k}
+					sort.Strings(pair)
+					if id := strings.Join(pair, "|"); !excluded[id] {
+						excluded[id] = true
+						allOf = append(allOf, map[string]any{"not": nonZeroSchema(map[string]reflect.Type{
+							key: f.Type,
+							k:   types[n],
+						})})
+					}
+				}
+			}
+		}
+
+		s["properties"].(map[string]any)[key] = fs
+	}
+
+	if len(required) > 0 {
+		s["required"] = required
+	}
+	if len(allOf) > 0 {
+		s["allOf"] = allOf
+	}
+	if len(dependent) > 0 {
+		s["dependentRequired"] = dependent
+	}
+	return s, nil
+}
+
+// nonZeroSchema returns the schema matched when every referenced property holds a non-zero value, as validator
+// considers it; a zero value (e.g. prefork: false) is as good as an absent property. Pointer(s) and struct(s) are
+// non-zero when present.
+func nonZeroSchema(fields map[string]reflect.Type) map[string]any {
+	var (
+		required   = make([]string, 0, len(fields))
+		properties = map[string]any{}
+	)
+	for k, t := range fields {
+		required = append(required, k)
+
+		switch t.Kind() {
+		case reflect.Bool:
+			properties[k] = map[string]any{"const": true}
+		case reflect.String:
+			properties[k] = map[string]any{"minLength": 1}
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+			reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+			reflect.Float32, reflect.Float64:
+			properties[k] = map[string]any{"not": map[string]any{"const": 0}}
+		case reflect.Slice, reflect.Array:
+			properties[k] = map[string]any{"minItems": 1}
+		case reflect.Map:
+			properties[k] = map[string]any{"minProperties": 1}
+		}
+	}
+	sort.Strings(required)
+
+	s := map[string]any{"required": required}
+	if len(properties) > 0 {
+		s["properties"] = properties
+	}
+	return s
+}
+
+// boundSchema applies the referenced bound validation to the schema of a field; numeric fields are bound by value,
+// strings by length and collections by item count.
+func boundSchema(s map[string]any, t reflect.Type, tag, param string) {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	n, err := strconv.ParseFloat(param, 64)
+	if err != nil {
+		return
+	}
+
+	var lower, upper, exclusiveLower, exclusiveUpper string
+	switch t.Kind() {
+	case reflect.String:
+		lower, upper = "minLength", "maxLength"
+	case reflect.Slice, reflect.Array:
+		lower, upper = "minItems", "maxItems"
+	case reflect.Map:
+		lower, upper = "minProperties", "maxProperties"
+	default:
+		// bound(s) of duration(s) | file mode(s) are not expressible as a number of the schema
+		if t == durationType || t == fileModeType {
+			return
+		}
+		lower, upper, exclusiveLower, exclusiveUpper = "minimum", "maximum", "exclusiveMinimum", "exclusiveMaximum"
+	}
+
+	switch tag {
+	case "min", "gte":
+		s[lower] = n
+	case "max", "lte":
+		s[upper] = n
+	case "len":
+		s[lower], s[upper] = n, n
+	case "gt":
+		if exclusiveLower != "" {
+			s[exclusiveLower] = n
+		} else {
+			s[lower] = n + 1
+		}
+	case "lt":
+		if exclusiveUpper != "" {
+			s[exclusiveUpper] = n
+		} else {
+			s[upper] = n - 1
+		}
+	}
+}
+
+// enumValues returns the referenced oneof value(s) typed according to the field type.
+func enumValues(t reflect.Type, values []string) []any {
+	enum := make([]any, 0, len(values))
+	for _, v := range values {
+		enum = append(enum, typedValue(t, v))
+	}
+	return enum
+}
+
+// typedValue returns the referenced struct tag value typed according to the field type; the value is returned as-is
+// when it cannot be converted.
+func typedValue(t reflect.Type, v string) any {
+	for t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == durationType || t == fileModeType {
+		return v
+	}
+
+	switch t.Kind() {
+	case reflect.Bool:
+		if b, err := strconv.ParseBool(v); err == nil {
+			return b
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		if n, err := strconv.ParseFloat(v, 64); err == nil {
+			return n
+		}
+	case reflect.Slice, reflect.Array:
+		values := []any{}
+		for _, e := range strings.Split(v, ",") {
+			if e = strings.TrimSpace(e); e != "" {
+				values = append(values, typedValue(t.Elem(), e))
+			}
+		}
+		return values
+	}
+	return v
+}
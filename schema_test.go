@@ -0,0 +1,129 @@
+package saddle
+
+import (
+	"reflect"
+	"regexp"
+	"testing"
+)
+
+type (
+	// schemaConfig contains the configuration reflected over by schema test(s).
+	schemaConfig struct {
+		Prefork bool       `mapstructure:"prefork" validate:"excluded_with=TLS"`
+		TLS     *schemaTLS `mapstructure:"tls" validate:"omitempty,excluded_with=Prefork"`
+		Host    string     `mapstructure:"host" validate:"excluded_with=Socket"`
+		Socket  *string    `mapstructure:"socket"`
+	}
+
+	// schemaTLS contains the nested block of schemaConfig.
+	schemaTLS struct {
+		CertFile string `mapstructure:"cert_file" validate:"required"`
+	}
+)
+
+func TestDurationPattern(t *testing.T) {
+	re := regexp.MustCompile(durationPattern)
+
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "0", want: true},
+		{value: "1m30s", want: true},
+		{value: "1.5h", want: true},
+		{value: "250ms", want: true},
+		{value: "10"},
+		{value: "1d"},
+		{value: ""},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := re.MatchString(tt.value); got != tt.want {
+				t.Errorf("match %q = %t; want %t", tt.value, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTypeSchemaDuration(t *testing.T) {
+	s, err := typeSchema(durationType)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// integer(s) decode as nanoseconds
+	if want := []string{"string", "integer"}; !reflect.DeepEqual(s["type"], want) {
+		t.Errorf("type = %v; want %v", s["type"], want)
+	}
+}
+
+func TestStructSchemaExcludedWith(t *testing.T) {
+	s, err := structSchema(reflect.TypeOf(schemaConfig{}), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	want := []any{
+		// prefork: false alongside tls is valid; only prefork: true excludes it
+		map[string]any{"not": map[string]any{
+			"required":   []string{"prefork", "tls"},
+			"properties": map[string]any{"prefork": map[string]any{"const": true}},
+		}},
+		map[string]any{"not": map[string]any{
+			"required":   []string{"host", "socket"},
+			"properties": map[string]any{"host": map[string]any{"minLength": 1}},
+		}},
+	}
+	if !reflect.DeepEqual(s["allOf"], want) {
+		t.Errorf("allOf = %v; want %v", s["allOf"], want)
+	}
+}
+
+func TestStructSchemaRequired(t *testing.T) {
+	type (
+		defaultedBlock struct {
+			Level string `mapstructure:"level" validate:"required" default:"info"`
+		}
+		requiredBlock struct {
+			Level string `mapstructure:"level" validate:"required" default:"info"`
+			URI   string `mapstructure:"uri" validate:"required"`
+		}
+		config struct {
+			Name      string          `mapstructure:"name" validate:"required"`
+			Port      int             `mapstructure:"port" validate:"required" default:"8080"`
+			Defaulted *defaultedBlock `mapstructure:"defaulted" validate:"required"`
+			Required  *requiredBlock  `mapstructure:"required" validate:"required"`
+		}
+	)
+
+	s, err := structSchema(reflect.TypeOf(config{}), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// field(s) satisfied by their default(s) may be left out of the configuration file(s)
+	if want := []string{"name", "required"}; !reflect.DeepEqual(s["required"], want) {
+		t.Errorf("required = %v; want %v", s["required"], want)
+	}
+}
+
+func TestFileModePattern(t *testing.T) {
+	re := regexp.MustCompile(fileModePattern)
+
+	tests := []struct {
+		value string
+		want  bool
+	}{
+		{value: "0660", want: true},
+		{value: "0o600", want: true},
+		{value: "0", want: true},
+		{value: "660"},
+		{value: "0999"},
+		{value: "01777"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.value, func(t *testing.T) {
+			if got := re.MatchString(tt.value); got != tt.want {
+				t.Errorf("match %q = %t; want %t", tt.value, got, tt.want)
+			}
+		})
+	}
+}
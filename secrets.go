@@ -0,0 +1,89 @@
+package saddle
+
+import (
+	"context"
+	"encoding/base64"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/spf13/viper"
+
+	"github.com/captjt/saddle/pkg/platform"
+)
+
+const (
+	// secretFileScheme references a secret read from a file (e.g. file:///var/run/secrets/db-password).
+	secretFileScheme = "file://"
+	// secretEnvScheme references a secret read from an environment variable (e.g. env://DB_PASSWORD).
+	secretEnvScheme = "env://"
+	// secretBase64Scheme references a base64 encoded secret (e.g. base64://aHVudGVyMg==).
+	secretBase64Scheme = "base64://"
+
+	// secretProblemTag contains the tag reported for secret reference(s) which cannot be resolved.
+	secretProblemTag = "secret"
+)
+
+// resolveSecrets resolves the secret reference(s) held by configuration value(s) in place; resolved secrets are
+// returned keyed by their configuration key so they can be redacted, and unresolvable reference(s) are appended to the
+// referenced configuration error.
+func resolveSecrets(vp *viper.Viper, ce *ConfigError) map[string]string {
+	secrets := map[string]string{}
+	for _, k := range vp.AllKeys() {
+		ref, ok := vp.Get(k).(string)
+		if !ok {
+			continue
+		}
+
+		v, ok, err := resolveSecret(ref)
+		if err != nil {
+			ce.Problems = append(ce.Problems, &FieldProblem{
+				Key:     k,
+				Tag:     secretProblemTag,
+				Message: fmt.Sprintf("unable to resolve secret reference: %s", err),
+			})
+			continue
+		}
+		if ok {
+			vp.Set(k, v)
+			secrets[k] = v
+		}
+	}
+	return secrets
+}
+
+// resolveSecret resolves the referenced value if it is a secret reference; ok is false for plain value(s).
+func resolveSecret(ref string) (string, bool, error) {
+	switch {
+	case strings.HasPrefix(ref, secretFileScheme):
+		b, err := platform.ReadFile(context.Background(), strings.TrimPrefix(ref, secretFileScheme))
+		if err != nil {
+			return "", true, err
+		}
+		// mounted secret(s) are often written with a trailing newline
+		return strings.TrimRight(string(b), "\r\n"), true, nil
+	case strings.HasPrefix(ref, secretEnvScheme):
+		name := strings.TrimPrefix(ref, secretEnvScheme)
+		v, ok := os.LookupEnv(name)
+		if !ok {
+			return "", true, fmt.Errorf("environment variable %s is not set", name)
+		}
+		return v, true, nil
+	case strings.HasPrefix(ref, secretBase64Scheme):
+		b, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(ref, secretBase64Scheme))
+		if err != nil {
+			return "", true, err
+		}
+		return string(b), true, nil
+	}
+	return ref, false, nil
+}
+
+// secretValues returns the resolved secret value(s) of the referenced secrets.
+func secretValues(secrets map[string]string) []string {
+	values := make([]string, 0, len(secrets))
+	for _, v := range secrets {
+		values = append(values, v)
+	}
+	return values
+}
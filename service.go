@@ -1,17 +1,23 @@
 package saddle
 
 import (
+	"context"
+	"crypto/tls"
+	"errors"
 	"fmt"
-	"os"
-	"os/signal"
-	"syscall"
+	"net"
+	"sync"
 	"time"
 
 	"github.com/go-playground/validator/v10"
 	"github.com/gofiber/fiber/v2"
+	"github.com/spf13/viper"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	"go.uber.org/zap"
 
 	"github.com/captjt/saddle/handlers"
 	"github.com/captjt/saddle/middleware"
+	"github.com/captjt/saddle/models"
 	log "github.com/captjt/saddle/pkg/logger"
 )
 
@@ -30,14 +36,100 @@ type (
 		Validator() *validator.Validate
 	}
 
+	// ContextAttacher is implemented by services wishing to learn when shutdown begins (e.g. to stop pollers |
+	// consumers); AttachContext is called instead of Attach with the root context of the project, which is cancelled on
+	// SIGINT | SIGTERM. In-flight request(s) keep their context until the drain deadline expires (see
+	// saddle.shutdown.drain_timeout); handlers reach the root context through ShutdownContext.
+	ContextAttacher interface {
+		// AttachContext attaches the service to execute | expose.
+		AttachContext(context.Context, *fiber.App, *log.Logger, *validator.Validate) (func(), error)
+	}
+
+	// FiberConfigurer is implemented by services wishing to adjust the Fiber config in code (e.g. set a custom
+	// ErrorHandler); ConfigureFiber is called with the config built from saddle.server before the app is created.
+	FiberConfigurer interface {
+		// ConfigureFiber adjusts the referenced Fiber config.
+		ConfigureFiber(*fiber.Config)
+	}
+
+	// Binder is implemented by services wishing to reference the project they are attached to (e.g. to look up the
+	// source of a configuration value); Bind is called before Attach.
+	Binder[T Service] interface {
+		// Bind binds the project to the service.
+		Bind(*Project[T])
+	}
+
+	// Reloadable is implemented by services which accept configuration changes without a restart. Reload receives a
+	// newly loaded and validated service configuration (of the type returned by Config) whenever the environment
+	// configuration file(s) change on disk or the process receives SIGHUP; returning an error rejects it. Reload is
+	// called from a background goroutine, so implementations must synchronize access to their configuration.
+	Reloadable interface {
+		// Reload hands the validated candidate configuration to the service.
+		Reload(config any) error
+	}
+
+	// Starter is implemented by services which acquire resource(s) before serving (e.g. warm caches, open database
+	// pools); Start is called after Attach and before the listener is up. Returning an error aborts startup.
+	Starter interface {
+		// Start starts the service; the context is cancelled once saddle.lifecycle.start_timeout elapses or shutdown
+		// begins.
+		Start(ctx context.Context) error
+	}
+
+	// ReadyNotifier is implemented by services which act once the listener is accepting connection(s) (e.g. register
+	// with service discovery). Returning an error stops the service.
+	ReadyNotifier interface {
+		// Ready notifies the service it is serving; the context is cancelled once saddle.lifecycle.ready_timeout
+		// elapses or shutdown begins.
+		Ready(ctx context.Context) error
+	}
+
+	// Stopper is implemented by services which release resource(s) acquired by Start (e.g. deregister from service
+	// discovery, close database pools); Stop is called after in-flight request(s) are drained and before the shutdown
+	// returned by Attach, and only once Start succeeded.
+	Stopper interface {
+		// Stop stops the service; the context is cancelled once saddle.lifecycle.stop_timeout elapses.
+		Stop(ctx context.Context) error
+	}
+
 	// Project contains elements, functions and references attached to a project.
 	Project[T Service] struct {
 		// App contains the referenced Fiber framework app instance attached to the project.
-		App       *fiber.App
+		App     *fiber.App
+		address string
+		// admin contains the Fiber app hosting the saddle-owned endpoint(s) on adminAddress (<name>.admin.address |
+		// saddle.admin.address); nil when they are hosted by App.
+		admin        *fiber.App
+		adminAddress string
+		// ctx contains the root context of the project; cancelled once shutdown begins.
+		ctx    context.Context
+		cancel context.CancelFunc
+		// requests contains the parent of each request context; cancelled once the drain deadline expires so in-flight
+		// request(s) complete while draining.
+		requests       context.Context
+		cancelRequests context.CancelFunc
+		health         *health
+		// listening is closed once the listener of the project is up.
+		listening chan struct{}
+		loaded    *loaded
+		loader    *loader
+		logger    *log.Logger
+		mu        sync.RWMutex
+		reloads   models.ReloadStatus
+		tracer    *sdktrace.TracerProvider
 		validator *validator.Validate
 
-		service  T
-		shutdown func(func())
+		service T
+		startup *startup
+		// tls contains the TLS config of the listener; nil when the listener is plaintext.
+		tls     *tls.Config
+		workers *workers
+		// started reports whether the service started (see Starter); Stop is only called on started service(s).
+		started bool
+		// shutdown contains the service-specific safe shutdown returned by Attach.
+		shutdown func()
+		// unwatch ends the watch of the configuration file(s) | SIGHUP (see watch); nil when not watched.
+		unwatch func()
 	}
 
 	Validate struct {
@@ -58,57 +150,389 @@ var (
 	version string
 )
 
+// flushTimeout contains the maximum duration to wait for pending span(s) to be exported during shutdown.
+const flushTimeout = 5 * time.Second
+
 func init() {
 	executedAt = time.Now().UTC()
 }
 
-// new instantiates a new project instance.
-func new[T Service](service T, logger *log.Logger, validator *validator.Validate) (
-	*Project[T], error,
-) {
+// new instantiates a new project instance; the root context of the project is derived from the referenced root (e.g.
+// cancelled on SIGINT | SIGTERM). The saddle-owned endpoint(s) are hosted on the referenced admin address, if any.
+func new[T Service](
+	root context.Context,
+	service T,
+	address string,
+	adminAddress string,
+	loader *loader,
+	loaded *loaded,
+	tracer *sdktrace.TracerProvider,
+	logger *log.Logger,
+	validator *validator.Validate,
+) (*Project[T], error) {
 
+	ctx, cancel := context.WithCancel(root)
+	requests, cancelRequests := context.WithCancel(context.Background())
 	s := &Project[T]{
-		App: fiber.New(fiber.Config{
-			ServerHeader: "Saddle",
-			AppName:      fmt.Sprintf("%s-%s", service.Name(), version),
-		}),
-		service:   service,
-		validator: validator,
+		App:            fiber.New(fiberConfig(service, loaded.config.Saddle.Server)),
+		address:        address,
+		adminAddress:   adminAddress,
+		cancel:         cancel,
+		cancelRequests: cancelRequests,
+		ctx:            ctx,
+		health:         &health{},
+		listening:      make(chan struct{}),
+		loaded:         loaded,
+		loader:         loader,
+		logger:         logger,
+		requests:       requests,
+		service:        service,
+		startup:        &startup{},
+		workers:        &workers{},
+		tracer:         tracer,
+		validator:      validator,
+	}
+
+	// - construct TLS config of the listener ↴
+	tc, err := serverTLSConfig(loaded.config.Saddle.Server.TLS, logger)
+	if err != nil {
+		return s, fmt.Errorf("unable to configure TLS: %w", err)
 	}
+	s.tls = tc
 
+	s.App.Use(middleware.Context(s.requests, s.ctx))
 	s.App.Use(middleware.RequestID())
+	s.App.Use(middleware.Trace(s.tracer, propagator, handlers.Skipper))
 	s.App.Use(middleware.RequestLog(logger))
+	s.App.Use(middleware.WarmUp(s.isStarted, s.isAdmin, s.retryAfter))
 
 	// route saddle-specific handlers ↴
-	h := handlers.New(&handlers.Config{
+	hc := &handlers.Config{
 		CompiledAt: compiledAt,
 		ExecutedAt: executedAt,
 		GitCommit:  gitCommit,
 		GitBranch:  gitBranch,
 		Version:    version,
-	},
+	}
+	if _, ok := any(service).(Reloadable); ok {
+		hc.Reloads = s.reloadStatus
+	}
+	hc.Readiness = s.readiness
+	hc.Startup = s.startupStatus
+	hc.Workers = s.workerStatus
+	h := handlers.New(hc,
 		logger,
 		s.validator,
 	)
-	h.Route(s.App, "")
-
-	// construct safe shutdown monitor ↴
-	s.shutdown = func(shutdown func()) {
-		if shutdown != nil {
-			c := make(chan os.Signal, 1)
-			signal.Notify(c, os.Interrupt, syscall.SIGTERM)
-			go func() {
-				defer logger.Sync() // flush any pending log(s) before exiting
-				<-c
-				logger.Info("initiating shutdown")
-				shutdown()
-				os.Exit(0)
-			}()
+
+	// - host saddle-specific handlers on the admin app, if any; the public app then hosts service route(s) only ↴
+	if s.adminAddress != "" {
+		s.admin = fiber.New(fiber.Config{
+			ServerHeader:          "Saddle",
+			AppName:               fmt.Sprintf("%s-%s-admin", service.Name(), version),
+			DisableStartupMessage: true,
+		})
+		s.admin.Use(middleware.Context(s.requests, s.ctx))
+		s.admin.Use(middleware.RequestID())
+		s.admin.Use(middleware.RequestLog(logger))
+		h.Route(s.admin, "")
+	} else {
+		h.Route(s.App, "")
+	}
+
+	s.App.Hooks().OnListen(func(fiber.ListenData) error {
+		close(s.listening)
+		return nil
+	})
+
+	// bind | attach service with service-specific safe shutdown ↴
+	if b, ok := any(s.service).(Binder[T]); ok {
+		b.Bind(s)
+	}
+	var sd func()
+	if ca, ok := any(s.service).(ContextAttacher); ok {
+		sd, err = ca.AttachContext(s.ctx, s.App, logger, s.validator)
+	} else {
+		sd, err = s.service.Attach(s.App, logger, s.validator)
+	}
+	s.shutdown = sd
+	if err != nil {
+		return s, fmt.Errorf("unable to attach: %w", err)
+	}
+
+	// - start service prior to serving ↴
+	if st, ok := any(s.service).(Starter); ok {
+		if err := runHook(s.ctx, loaded.config.Saddle.Lifecycle.StartTimeout, st.Start); err != nil {
+			return s, fmt.Errorf("unable to start: %w", err)
+		}
+	}
+	s.started = true
+	return s, nil
+}
+
+// fiberConfig returns the Fiber config of the referenced service built from the saddle.server configuration, adjusted
+// by the service when it is a FiberConfigurer.
+func fiberConfig(service Service, server models.Server) fiber.Config {
+	fc := fiber.Config{
+		ServerHeader:            "Saddle",
+		AppName:                 fmt.Sprintf("%s-%s", service.Name(), version),
+		ReadTimeout:             server.ReadTimeout,
+		WriteTimeout:            server.WriteTimeout,
+		IdleTimeout:             server.IdleTimeout,
+		BodyLimit:               server.BodyLimit,
+		Concurrency:             server.Concurrency,
+		ProxyHeader:             server.ProxyHeader,
+		EnableTrustedProxyCheck: len(server.TrustedProxies) > 0,
+		TrustedProxies:          server.TrustedProxies,
+		Prefork:                 server.Prefork,
+	}
+	if c, ok := service.(FiberConfigurer); ok {
+		c.ConfigureFiber(&fc)
+	}
+	return fc
+}
+
+// serve listens for incoming requests on the address of the project until the project is stopped; once the listener is
+// up the registered warm-up task(s) are run, then the service is notified it is serving (see ReadyNotifier). Both
+// are abandoned once shutdown begins.
+func (p *Project[T]) serve() error {
+	p.logger.Info("listening for requests",
+		zap.String("address", p.address),
+		zap.Bool("tls", p.tls != nil),
+	)
+
+	// prefork child process(es) share a plaintext TCP address; neither a unix:// | fd:// address, a TLS listener nor an
+	// admin listener can be shared. A FiberConfigurer may enable prefork, bypassing the validation of saddle.server.
+	if p.App.Config().Prefork {
+		switch {
+		case !isTCP(p.address):
+			return fmt.Errorf("prefork requires a TCP address; got %s", p.address)
+		case p.tls != nil:
+			return errors.New("prefork is not supported with TLS")
+		case p.admin != nil:
+			return errors.New("prefork is not supported with an admin listener")
+		}
+	}
+
+	// prefork child process(es) never run the listen hook(s); their listener is up once Listen is called
+	if p.App.Config().Prefork && fiber.IsChild() {
+		close(p.listening)
+	}
+
+	// - listen on the admin address first; saddle-owned endpoint(s) are served while the service warms up ↴
+	listened := make(chan error, 2)
+	if p.admin != nil {
+		ln, err := p.adminListener()
+		if err != nil {
+			return err
+		}
+		p.logger.Info("listening for admin requests",
+			zap.String("address", p.adminAddress),
+		)
+		go func() {
+			listened <- p.admin.Listener(ln)
+		}()
+	}
+
+	// - listen on the address; prefork listens on its own as child process(es) share the address ↴
+	listen := func() error {
+		return p.App.Listen(p.address)
+	}
+	if !p.App.Config().Prefork {
+		ln, err := p.listener()
+		if err != nil {
+			return err
+		}
+		listen = func() error {
+			return p.App.Listener(ln)
+		}
+	}
+
+	go func() {
+		listened <- listen()
+	}()
+
+	select {
+	case err := <-listened:
+		return err
+	case <-p.listening:
+	}
+
+	// - warm up service prior to accepting non-admin request(s) ↴
+	err := p.warmUp()
+	// shutdown began while warming up; the service is neither failed nor ready
+	if p.ctx.Err() != nil {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("unable to warm up %s: %w", p.service.Name(), err)
+	}
+
+	// - notify service it is serving ↴
+	if rn, ok := any(p.service).(ReadyNotifier); ok {
+		p.mu.RLock()
+		timeout := p.loaded.config.Saddle.Lifecycle.ReadyTimeout
+		p.mu.RUnlock()
+
+		if err := runHook(p.ctx, timeout, rn.Ready); err != nil && p.ctx.Err() == nil {
+			return fmt.Errorf("unable to notify %s it is ready: %w", p.service.Name(), err)
+		}
+	}
+	return <-listened
+}
+
+// listener constructs the listener of the project on its TCP | unix:// | fd:// address (see listen); wrapped with TLS
+// when configured.
+func (p *Project[T]) listener() (net.Listener, error) {
+	p.mu.RLock()
+	mode := p.loaded.config.Saddle.Server.SocketMode
+	p.mu.RUnlock()
+
+	ln, err := listen(p.App.Config().Network, p.address, mode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen: %w", err)
+	}
+	if p.tls != nil {
+		ln = tls.NewListener(ln, p.tls)
+	}
+	return ln, nil
+}
+
+// adminListener constructs the (plaintext) listener of the admin app on saddle.admin.address (see listen).
+func (p *Project[T]) adminListener() (net.Listener, error) {
+	p.mu.RLock()
+	mode := p.loaded.config.Saddle.Server.SocketMode
+	p.mu.RUnlock()
+
+	ln, err := listen(p.admin.Config().Network, p.adminAddress, mode)
+	if err != nil {
+		return nil, fmt.Errorf("failed to listen on admin address: %w", err)
+	}
+	return ln, nil
+}
+
+// adminAddr returns the address of the admin listener of the project; empty when the saddle-owned endpoint(s) are
+// hosted by the public listener.
+func (p *Project[T]) adminAddr() string {
+	return p.adminAddress
+}
+
+// isAdmin reports whether the request references a saddle-owned route hosted by the public app; such route(s) are
+// served while the service warms up.
+func (p *Project[T]) isAdmin(c *fiber.Ctx) bool {
+	return p.admin == nil && handlers.Admin(c)
+}
+
+// stop stops accepting connection(s) and drains in-flight request(s) within the configured drain timeout, then runs
+// the service-specific safe shutdown and flushes any pending span(s) | log(s); a drain | flush failure is returned.
+func (p *Project[T]) stop() error {
+	defer p.logger.Sync() // flush any pending log(s) before returning
+
+	p.mu.RLock()
+	drain, stop := p.loaded.config.Saddle.Shutdown.DrainTimeout, p.loaded.config.Saddle.Lifecycle.StopTimeout
+	p.mu.RUnlock()
+
+	p.logger.Info("initiating shutdown",
+		zap.Duration("drain_timeout", drain),
+	)
+	// a stopping service must not be reloaded
+	if p.unwatch != nil {
+		p.unwatch()
+	}
+	// notify background goroutine(s) that shutdown began
+	p.cancel()
+	// in-flight request(s) not drained in time are cancelled; the service-specific shutdown follows
+	defer p.cancelRequests()
+
+	// - stop accepting connection(s); drain in-flight request(s), cancelling them once the drain deadline expires ↴
+	var errs []error
+	ctx, cancel := deadline(context.Background(), drain)
+	if err := p.App.ShutdownWithContext(ctx); err != nil {
+		p.cancelRequests()
+		p.logger.Error("unable to drain in-flight request(s)",
+			zap.Error(err),
+		)
+		errs = append(errs, fmt.Errorf("unable to drain %s: %w", p.service.Name(), err))
+	}
+	cancel()
+
+	// - stop the admin app once the public app drained; probe(s) are answered throughout ↴
+	if p.admin != nil {
+		ctx, cancel := deadline(context.Background(), drain)
+		if err := p.admin.ShutdownWithContext(ctx); err != nil {
+			p.cancelRequests()
+			p.logger.Error("unable to drain in-flight admin request(s)",
+				zap.Error(err),
+			)
+			errs = append(errs, fmt.Errorf("unable to drain admin app of %s: %w", p.service.Name(), err))
 		}
+		cancel()
+	}
+
+	// - wait for background worker(s) to return ↴
+	if err := p.waitWorkers(stop); err != nil {
+		p.logger.Error("unable to stop worker(s)",
+			zap.Error(err),
+		)
+		errs = append(errs, fmt.Errorf("unable to stop worker(s) of %s: %w", p.service.Name(), err))
+	}
+
+	// - stop started service ↴
+	if st, ok := any(p.service).(Stopper); ok && p.started {
+		if err := runHook(context.Background(), stop, st.Stop); err != nil {
+			p.logger.Error("unable to stop service",
+				zap.Error(err),
+			)
+			errs = append(errs, fmt.Errorf("unable to stop %s: %w", p.service.Name(), err))
+		}
+	}
+
+	// - run service-specific safe shutdown ↴
+	if p.shutdown != nil {
+		p.shutdown()
 	}
 
-	// attach service with service-specific safe shutdown ↴
-	sd, err := s.service.Attach(s.App, logger, s.validator)
-	s.shutdown(sd)
-	return s, err
+	// - flush any pending span(s) ↴
+	ctx, cancel = context.WithTimeout(context.Background(), flushTimeout)
+	defer cancel()
+	if err := p.tracer.Shutdown(ctx); err != nil {
+		p.logger.Error("unable to flush trace exporter",
+			zap.Error(err),
+		)
+		errs = append(errs, fmt.Errorf("unable to flush trace exporter of %s: %w", p.service.Name(), err))
+	}
+	return errors.Join(errs...)
+}
+
+// Context returns the root context of the project; cancelled once shutdown begins (e.g. on SIGINT | SIGTERM). Request
+// contexts remain intact while in-flight request(s) drain and are cancelled once the drain deadline expires; handlers
+// reach the root context through ShutdownContext.
+func (p *Project[T]) Context() context.Context {
+	return p.ctx
+}
+
+// ShutdownContext returns the root context of the project serving the referenced request, cancelled as soon as
+// shutdown begins (e.g. so a long-poll | stream handler returns early); the request context (c.UserContext()) is only
+// cancelled once the drain deadline expires. A background context is returned outside of a project.
+func ShutdownContext(c *fiber.Ctx) context.Context {
+	if ctx, ok := c.Locals(middleware.CTXShutdown).(context.Context); ok {
+		return ctx
+	}
+	return context.Background()
+}
+
+// Provenance returns the source of each loaded configuration key of the project.
+func (p *Project[T]) Provenance() Provenance {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.loaded.provenance
+}
+
+// Viper returns the isolated viper instance holding the loaded configuration of the project.
+func (p *Project[T]) Viper() *viper.Viper {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.loaded.viper
 }
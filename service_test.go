@@ -0,0 +1,309 @@
+package saddle
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sync"
+	"testing"
+	"testing/fstest"
+	"time"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+
+	log "github.com/captjt/saddle/pkg/logger"
+)
+
+type (
+	// lifecycleService contains a service recording the lifecycle event(s) it observes; its /slow route blocks until
+	// release is closed or its context is cancelled, then sends the error of its context to requestErr.
+	lifecycleService struct {
+		testService
+
+		mu         sync.Mutex
+		events     []string
+		entered    chan struct{}
+		release    chan struct{}
+		requestErr chan error
+	}
+)
+
+func newLifecycleService() *lifecycleService {
+	return &lifecycleService{
+		entered:    make(chan struct{}, 1),
+		release:    make(chan struct{}),
+		requestErr: make(chan error, 1),
+	}
+}
+
+func (s *lifecycleService) Attach(app *fiber.App, _ *log.Logger, _ *validator.Validate) (func(), error) {
+	app.Get("/slow", func(c *fiber.Ctx) error {
+		s.entered <- struct{}{}
+		select {
+		case <-s.release:
+			s.record("request")
+		case <-c.UserContext().Done():
+		}
+		s.requestErr <- c.UserContext().Err()
+		return c.SendStatus(http.StatusOK)
+	})
+	return func() { s.record("shutdown") }, nil
+}
+
+func (s *lifecycleService) ConfigureFiber(fc *fiber.Config) {
+	fc.DisableStartupMessage = true
+}
+
+// record records the referenced lifecycle event.
+func (s *lifecycleService) record(event string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.events = append(s.events, event)
+}
+
+// recorded returns a copy of the recorded lifecycle event(s).
+func (s *lifecycleService) recorded() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return append([]string{}, s.events...)
+}
+
+// freeAddress returns a loopback TCP address no listener is bound to.
+func freeAddress(t *testing.T) string {
+	t.Helper()
+
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ln.Close()
+	return ln.Addr().String()
+}
+
+// testProject loads the referenced (embedded) test environment configuration of the referenced service and attaches the
+// service to a new project listening on a free loopback address; the error of new is returned as-is.
+func testProject[T Service](t *testing.T, service T, config string) (*Project[T], error) {
+	t.Helper()
+
+	o := newOptions(
+		WithFS(fstest.MapFS{"test.yaml": &fstest.MapFile{Data: []byte(config)}}),
+		WithSearchPaths(t.TempDir()),
+	)
+	l := &loader{
+		environment: "test",
+		options:     o,
+		paths:       o.searchPaths,
+		service:     service.Name(),
+	}
+	ld, err := l.load(service.Config())
+	if err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	return new(context.Background(), service, freeAddress(t), ld.config.Saddle.Admin.Address, l, ld,
+		sdktrace.NewTracerProvider(), log.New(log.Production, "svc"), validator.New())
+}
+
+// serveProject serves the referenced project in the background until it is stopped, returning once it is accepting
+// connection(s); the result of serve is sent to the returned channel.
+func serveProject[T Service](t *testing.T, p *Project[T]) <-chan error {
+	t.Helper()
+
+	served := make(chan error, 1)
+	go func() {
+		served <- p.serve()
+	}()
+
+	timeout := time.After(5 * time.Second)
+	for !p.isStarted() {
+		select {
+		case err := <-served:
+			t.Fatalf("serve: %v", err)
+		case <-timeout:
+			t.Fatal("project did not start")
+		case <-time.After(10 * time.Millisecond):
+		}
+	}
+	return served
+}
+
+func TestProjectStopDrain(t *testing.T) {
+	tests := []struct {
+		name           string
+		drain          string
+		release        bool
+		want           []string
+		wantErr        bool
+		wantRequestErr error
+	}{
+		{
+			// shutdown began, yet the request context is intact while draining
+			name:    "in-flight request completes",
+			drain:   "5s",
+			release: true,
+			want:    []string{"request", "shutdown"},
+		},
+		{
+			name:           "drain deadline expires",
+			drain:          "100ms",
+			want:           []string{"shutdown"},
+			wantErr:        true,
+			wantRequestErr: context.Canceled,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			svc := newLifecycleService()
+			p, err := testProject(t, svc, "saddle: {shutdown: {drain_timeout: "+tt.drain+"}}")
+			if err != nil {
+				t.Fatalf("new: %v", err)
+			}
+			served := serveProject(t, p)
+
+			// - hold a request in flight while stopping ↴
+			client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+			responded := make(chan error, 1)
+			go func() {
+				resp, err := client.Get("http://" + p.address + "/slow")
+				if err == nil {
+					resp.Body.Close()
+					if resp.StatusCode != http.StatusOK {
+						err = errors.New(resp.Status)
+					}
+				}
+				responded <- err
+			}()
+			<-svc.entered
+
+			stopped := make(chan error, 1)
+			go func() {
+				stopped <- p.stop()
+			}()
+
+			// - connection(s) are refused once stopping ↴
+			refused := time.After(5 * time.Second)
+			for {
+				c, err := net.Dial("tcp", p.address)
+				if err != nil {
+					break
+				}
+				c.Close()
+				select {
+				case <-refused:
+					t.Fatal("stopping project accepted a connection")
+				case <-time.After(10 * time.Millisecond):
+				}
+			}
+
+			if tt.release {
+				close(svc.release)
+				if err := <-responded; err != nil {
+					t.Errorf("in-flight request: %v", err)
+				}
+			}
+			if err := <-svc.requestErr; !errors.Is(err, tt.wantRequestErr) {
+				t.Errorf("request context error = %v; want %v", err, tt.wantRequestErr)
+			}
+			if err := <-stopped; (err != nil) != tt.wantErr {
+				t.Errorf("stop error = %v; want error %t", err, tt.wantErr)
+			}
+			// the service-specific shutdown runs once draining ended
+			if got := svc.recorded(); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("events = %v; want %v", got, tt.want)
+			}
+
+			if !tt.release {
+				close(svc.release)
+			}
+			if err := <-served; err != nil {
+				t.Errorf("serve: %v", err)
+			}
+		})
+	}
+}
+
+type (
+	// shutdownService contains a service whose /poll route blocks until shutdown begins, then sends the error of its
+	// request context at that moment to requestErr.
+	shutdownService struct {
+		*lifecycleService
+	}
+)
+
+func (s *shutdownService) Attach(app *fiber.App, _ *log.Logger, _ *validator.Validate) (func(), error) {
+	app.Get("/poll", func(c *fiber.Ctx) error {
+		s.entered <- struct{}{}
+		<-ShutdownContext(c).Done()
+		s.requestErr <- c.UserContext().Err()
+		return c.SendStatus(http.StatusOK)
+	})
+	return func() { s.record("shutdown") }, nil
+}
+
+func TestProjectShutdownContext(t *testing.T) {
+	svc := &shutdownService{lifecycleService: newLifecycleService()}
+	p, err := testProject(t, svc, "saddle: {shutdown: {drain_timeout: 5s}}")
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	served := serveProject(t, p)
+
+	responded := make(chan error, 1)
+	go func() {
+		client := &http.Client{Transport: &http.Transport{DisableKeepAlives: true}}
+		resp, err := client.Get("http://" + p.address + "/poll")
+		if err == nil {
+			resp.Body.Close()
+			if resp.StatusCode != http.StatusOK {
+				err = errors.New(resp.Status)
+			}
+		}
+		responded <- err
+	}()
+	<-svc.entered
+
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- p.stop()
+	}()
+
+	// the handler learns of shutdown as soon as it begins, while its request context is intact for draining
+	select {
+	case err := <-svc.requestErr:
+		if err != nil {
+			t.Errorf("request context error = %v; want nil", err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("handler did not observe shutdown")
+	}
+	if err := <-responded; err != nil {
+		t.Errorf("request: %v", err)
+	}
+	if err := <-stopped; err != nil {
+		t.Errorf("stop: %v", err)
+	}
+	if err := <-served; err != nil {
+		t.Errorf("serve: %v", err)
+	}
+
+	// outside of a project, the shutdown context is never cancelled
+	app := fiber.New()
+	app.Get("/", func(c *fiber.Ctx) error {
+		if ShutdownContext(c).Done() != nil {
+			return errors.New("cancellable shutdown context")
+		}
+		return nil
+	})
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, "/", nil))
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Errorf("request outside of a project = %v, %v; want %d", resp, err, http.StatusOK)
+	}
+}
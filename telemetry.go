@@ -0,0 +1,185 @@
+package saddle
+
+import (
+	"context"
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"io"
+	"net/url"
+	"os"
+	"strings"
+
+	texporter "github.com/GoogleCloudPlatform/opentelemetry-operations-go/exporter/trace"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracegrpc"
+	"go.opentelemetry.io/otel/exporters/otlp/otlptrace/otlptracehttp"
+	"go.opentelemetry.io/otel/exporters/stdout/stdouttrace"
+	"go.opentelemetry.io/otel/propagation"
+	"go.opentelemetry.io/otel/sdk/resource"
+	sdktrace "go.opentelemetry.io/otel/sdk/trace"
+	semconv "go.opentelemetry.io/otel/semconv/v1.24.0"
+	"google.golang.org/grpc/credentials"
+
+	"github.com/captjt/saddle/models"
+)
+
+const (
+	// otlpTracesPath contains the default OTLP/HTTP path in which to export traces.
+	otlpTracesPath = "/v1/traces"
+
+	// otlpProtocolGRPC contains the OTLP protocol identifier for exporting over gRPC.
+	otlpProtocolGRPC = "grpc"
+	// otlpCompressionGzip contains the OTLP compression identifier for gzip.
+	otlpCompressionGzip = "gzip"
+)
+
+// propagator contains the W3C trace context and baggage propagator used to extract | inject trace context.
+var propagator = propagation.NewCompositeTextMapPropagator(propagation.TraceContext{}, propagation.Baggage{})
+
+// stdout contains the writer the stdout open-telemetry exporter writes span(s) to.
+var stdout io.Writer = os.Stdout
+
+// tracerProvider constructs an open-telemetry tracer provider from whichever exporter is configured within the saddle
+// configuration; a provider which never samples is returned when no exporter (or None) is configured.
+func tracerProvider(ctx context.Context, config *models.Config, service Service, environment string) (
+	*sdktrace.TracerProvider, error,
+) {
+	res, err := resource.Merge(resource.Default(), resource.NewWithAttributes(semconv.SchemaURL,
+		semconv.ServiceName(service.Name()),
+		semconv.ServiceVersion(version),
+		semconv.DeploymentEnvironment(environment),
+	))
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		exporter    sdktrace.SpanExporter
+		sampleRate  float64
+		synchronous bool
+	)
+
+	switch sc := config.Saddle; {
+	case sc.CloudTrace != nil:
+		if exporter, err = texporter.New(texporter.WithProjectID(sc.CloudTrace.ProjectID)); err != nil {
+			return nil, err
+		}
+		sampleRate = sc.CloudTrace.SampleRate
+	case sc.Jaeger != nil:
+		// - Jaeger® ingests OTLP natively; export over OTLP/HTTP to the referenced collector ↴
+		if exporter, err = otlptracehttp.New(ctx, otlptracehttp.WithEndpointURL(otlpEndpoint(sc.Jaeger.URI))); err != nil {
+			return nil, err
+		}
+		sampleRate = sc.Jaeger.SampleRate
+	case sc.OTLP != nil:
+		if exporter, err = otlpExporter(ctx, sc.OTLP); err != nil {
+			return nil, err
+		}
+		sampleRate = sc.OTLP.SampleRate
+	case sc.StdOut != nil:
+		if exporter, err = stdouttrace.New(stdouttrace.WithWriter(stdout), stdouttrace.WithPrettyPrint()); err != nil {
+			return nil, err
+		}
+		sampleRate, synchronous = sc.StdOut.SampleRate, true
+	default:
+		return sdktrace.NewTracerProvider(
+			sdktrace.WithResource(res),
+			sdktrace.WithSampler(sdktrace.NeverSample()),
+		), nil
+	}
+
+	options := []sdktrace.TracerProviderOption{
+		sdktrace.WithResource(res),
+		sdktrace.WithSampler(sdktrace.ParentBased(sdktrace.TraceIDRatioBased(sampleRate / 100))),
+	}
+	if synchronous {
+		// export span(s) as they end so they are immediately visible while developing locally
+		options = append(options, sdktrace.WithSyncer(exporter))
+	} else {
+		options = append(options, sdktrace.WithBatcher(exporter))
+	}
+	return sdktrace.NewTracerProvider(options...), nil
+}
+
+// otlpExporter constructs an OTLP span exporter over the configured protocol.
+func otlpExporter(ctx context.Context, config *models.OTLP) (sdktrace.SpanExporter, error) {
+	var (
+		tc  *tls.Config
+		err error
+	)
+	if config.TLS != nil {
+		if tc, err = otlpTLSConfig(config.TLS); err != nil {
+			return nil, err
+		}
+	}
+
+	if config.Protocol == otlpProtocolGRPC {
+		options := []otlptracegrpc.Option{
+			otlptracegrpc.WithEndpointURL(config.Endpoint),
+			otlptracegrpc.WithHeaders(config.Headers),
+		}
+		switch {
+		case config.Insecure:
+			options = append(options, otlptracegrpc.WithInsecure())
+		case tc != nil:
+			options = append(options, otlptracegrpc.WithTLSCredentials(credentials.NewTLS(tc)))
+		}
+		if config.Compression == otlpCompressionGzip {
+			options = append(options, otlptracegrpc.WithCompressor(otlpCompressionGzip))
+		}
+		return otlptracegrpc.New(ctx, options...)
+	}
+
+	options := []otlptracehttp.Option{
+		otlptracehttp.WithEndpointURL(otlpEndpoint(config.Endpoint)),
+		otlptracehttp.WithHeaders(config.Headers),
+	}
+	switch {
+	case config.Insecure:
+		options = append(options, otlptracehttp.WithInsecure())
+	case tc != nil:
+		options = append(options, otlptracehttp.WithTLSClientConfig(tc))
+	}
+	if config.Compression == otlpCompressionGzip {
+		options = append(options, otlptracehttp.WithCompression(otlptracehttp.GzipCompression))
+	}
+	return otlptracehttp.New(ctx, options...)
+}
+
+// otlpTLSConfig constructs the client transport security configuration used to export to an OTLP collector.
+func otlpTLSConfig(config *models.OTLPTLS) (*tls.Config, error) {
+	tc := &tls.Config{
+		MinVersion: tls.VersionTLS12,
+		ServerName: config.ServerName,
+	}
+
+	if config.CAFile != "" {
+		ca, err := os.ReadFile(config.CAFile)
+		if err != nil {
+			return nil, err
+		}
+		tc.RootCAs = x509.NewCertPool()
+		if !tc.RootCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificate(s) found within otlp ca file: %s", config.CAFile)
+		}
+	}
+	if config.CertFile != "" {
+		cert, err := tls.LoadX509KeyPair(config.CertFile, config.KeyFile)
+		if err != nil {
+			return nil, err
+		}
+		tc.Certificates = []tls.Certificate{cert}
+	}
+	return tc, nil
+}
+
+// otlpEndpoint returns the OTLP/HTTP trace endpoint of the referenced URI; the default trace path is appended when the
+// URI does not specify one.
+func otlpEndpoint(uri string) string {
+	u, err := url.Parse(uri)
+	if err != nil || strings.Trim(u.Path, "/") != "" {
+		return uri
+	}
+	u.Path = otlpTracesPath
+	return u.String()
+}
@@ -0,0 +1,268 @@
+package saddle
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/go-playground/validator/v10"
+	"github.com/gofiber/fiber/v2"
+	coltracepb "go.opentelemetry.io/proto/otlp/collector/trace/v1"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/encoding"
+	grpcgzip "google.golang.org/grpc/encoding/gzip"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/protobuf/proto"
+
+	"github.com/captjt/saddle/models"
+	log "github.com/captjt/saddle/pkg/logger"
+)
+
+type (
+	// testService contains a service attached by test(s).
+	testService struct {
+		config any
+	}
+
+	// collector contains a local OTLP collector stand-in recording the span(s) and header(s) it receives.
+	collector struct {
+		coltracepb.UnimplementedTraceServiceServer
+
+		mu      sync.Mutex
+		spans   []string
+		headers map[string]string
+		gzip    bool
+	}
+
+	// countingCompressor contains a gRPC compressor counting the message(s) it decompresses.
+	countingCompressor struct {
+		encoding.Compressor
+		decompressed atomic.Int64
+	}
+)
+
+func (s *testService) Attach(*fiber.App, *log.Logger, *validator.Validate) (func(), error) {
+	return func() {}, nil
+}
+
+func (s *testService) Config() any {
+	if s.config == nil {
+		s.config = &testConfig{}
+	}
+	return s.config
+}
+
+func (s *testService) Description() string            { return "test service" }
+func (s *testService) Name() string                   { return "svc" }
+func (s *testService) Validator() *validator.Validate { return validator.New() }
+
+func (c *countingCompressor) Decompress(r io.Reader) (io.Reader, error) {
+	c.decompressed.Add(1)
+	return c.Compressor.Decompress(r)
+}
+
+// record records the span(s) of the referenced export request along with the referenced header(s).
+func (c *collector) record(req *coltracepb.ExportTraceServiceRequest, headers map[string]string, gzip bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	for _, rs := range req.GetResourceSpans() {
+		for _, ss := range rs.GetScopeSpans() {
+			for _, s := range ss.GetSpans() {
+				c.spans = append(c.spans, s.GetName())
+			}
+		}
+	}
+	c.headers, c.gzip = headers, gzip
+}
+
+// Export records the span(s) of an OTLP/gRPC export request.
+func (c *collector) Export(ctx context.Context, req *coltracepb.ExportTraceServiceRequest) (
+	*coltracepb.ExportTraceServiceResponse, error,
+) {
+	headers := map[string]string{}
+	md, _ := metadata.FromIncomingContext(ctx)
+	for k, v := range md {
+		headers[k] = v[0]
+	}
+	c.record(req, headers, false)
+	return &coltracepb.ExportTraceServiceResponse{}, nil
+}
+
+// ServeHTTP records the span(s) of an OTLP/HTTP export request.
+func (c *collector) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	body := io.Reader(r.Body)
+	gz := r.Header.Get("Content-Encoding") == "gzip"
+	if gz {
+		zr, err := gzip.NewReader(r.Body)
+		if err != nil {
+			http.Error(w, err.Error(), http.StatusBadRequest)
+			return
+		}
+		body = zr
+	}
+	b, err := io.ReadAll(body)
+	if err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+
+	req := &coltracepb.ExportTraceServiceRequest{}
+	if err := proto.Unmarshal(b, req); err != nil {
+		http.Error(w, err.Error(), http.StatusBadRequest)
+		return
+	}
+	headers := map[string]string{}
+	for k := range r.Header {
+		headers[http.CanonicalHeaderKey(k)] = r.Header.Get(k)
+	}
+	c.record(req, headers, gz)
+
+	resp, _ := proto.Marshal(&coltracepb.ExportTraceServiceResponse{})
+	w.Header().Set("Content-Type", "application/x-protobuf")
+	_, _ = w.Write(resp)
+}
+
+func TestTracerProviderOTLP(t *testing.T) {
+	cc := &countingCompressor{Compressor: encoding.GetCompressor(grpcgzip.Name)}
+	encoding.RegisterCompressor(cc)
+
+	tests := []struct {
+		name        string
+		protocol    string
+		compression string
+		header      string
+	}{
+		{name: "http", protocol: "http/protobuf", compression: "none", header: "Authorization"},
+		{name: "http gzip", protocol: "http/protobuf", compression: "gzip", header: "Authorization"},
+		{name: "grpc", protocol: "grpc", compression: "none", header: "authorization"},
+		{name: "grpc gzip", protocol: "grpc", compression: "gzip", header: "authorization"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c := &collector{}
+
+			// - start collector stand-in of the protocol ↴
+			var endpoint string
+			if tt.protocol == otlpProtocolGRPC {
+				ln, err := net.Listen("tcp", "127.0.0.1:0")
+				if err != nil {
+					t.Fatal(err)
+				}
+				s := grpc.NewServer()
+				coltracepb.RegisterTraceServiceServer(s, c)
+				go func() { _ = s.Serve(ln) }()
+				t.Cleanup(s.Stop)
+				endpoint = "http://" + ln.Addr().String()
+			} else {
+				s := httptest.NewServer(c)
+				t.Cleanup(s.Close)
+				endpoint = s.URL
+			}
+
+			config := &models.Config{}
+			config.Saddle.OTLP = &models.OTLP{
+				Endpoint:    endpoint,
+				Protocol:    tt.protocol,
+				Headers:     map[string]string{"authorization": "Bearer hunter2"},
+				Insecure:    true,
+				Compression: tt.compression,
+				SampleRate:  100,
+			}
+
+			before := cc.decompressed.Load()
+			tp, err := tracerProvider(context.Background(), config, &testService{}, "test")
+			if err != nil {
+				t.Fatalf("tracerProvider: %v", err)
+			}
+			_, span := tp.Tracer("test").Start(context.Background(), "operation")
+			span.End()
+			if err := tp.Shutdown(context.Background()); err != nil {
+				t.Fatalf("flush: %v", err)
+			}
+
+			c.mu.Lock()
+			defer c.mu.Unlock()
+
+			if len(c.spans) != 1 || c.spans[0] != "operation" {
+				t.Errorf("spans = %v; want [operation]", c.spans)
+			}
+			if got := c.headers[tt.header]; got != "Bearer hunter2" {
+				t.Errorf("header %s = %q; want %q", tt.header, got, "Bearer hunter2")
+			}
+
+			gz := c.gzip
+			if tt.protocol == otlpProtocolGRPC {
+				gz = cc.decompressed.Load() > before
+			}
+			if want := tt.compression == otlpCompressionGzip; gz != want {
+				t.Errorf("gzip = %t; want %t", gz, want)
+			}
+		})
+	}
+}
+
+func TestTracerProviderSampling(t *testing.T) {
+	tests := []struct {
+		name        string
+		config      func(*models.Config)
+		wantSampled bool
+	}{
+		{
+			name:   "unconfigured never samples",
+			config: func(*models.Config) {},
+		},
+		{
+			name:   "none never samples",
+			config: func(c *models.Config) { c.Saddle.None = &models.None{Disabled: true} },
+		},
+		{
+			name:        "stdout samples every trace",
+			config:      func(c *models.Config) { c.Saddle.StdOut = &models.StdOut{SampleRate: 100} },
+			wantSampled: true,
+		},
+		{
+			// the sample rate is a percentage applied as a trace id ratio
+			name:   "stdout samples no trace",
+			config: func(c *models.Config) { c.Saddle.StdOut = &models.StdOut{SampleRate: 0} },
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			out := &bytes.Buffer{}
+			defer func(w io.Writer) { stdout = w }(stdout)
+			stdout = out
+
+			config := &models.Config{}
+			tt.config(config)
+			tp, err := tracerProvider(context.Background(), config, &testService{}, "test")
+			if err != nil {
+				t.Fatalf("tracerProvider: %v", err)
+			}
+			_, span := tp.Tracer("test").Start(context.Background(), "operation")
+			sampled := span.SpanContext().IsSampled()
+			span.End()
+			if err := tp.Shutdown(context.Background()); err != nil {
+				t.Fatalf("flush: %v", err)
+			}
+
+			if sampled != tt.wantSampled {
+				t.Errorf("sampled = %t; want %t", sampled, tt.wantSampled)
+			}
+			// the stdout exporter writes sampled span(s) synchronously
+			if got := strings.Contains(out.String(), `"Name": "operation"`); got != tt.wantSampled {
+				t.Errorf("span written = %t; want %t: %s", got, tt.wantSampled, out)
+			}
+		})
+	}
+}
@@ -0,0 +1,151 @@
+package saddle
+
+import (
+	"crypto/tls"
+	"crypto/x509"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/captjt/saddle/models"
+	log "github.com/captjt/saddle/pkg/logger"
+)
+
+// certCheckInterval contains the minimum interval between checks of the certificate file(s) for rotation.
+const certCheckInterval = time.Second
+
+type (
+	// certReloader serves the certificate of the listener, reloading it once the certificate | key file(s) are
+	// rotated on disk.
+	certReloader struct {
+		certFile string
+		keyFile  string
+		logger   *log.Logger
+
+		mu        sync.Mutex
+		cert      *tls.Certificate
+		checkedAt time.Time
+		modTime   time.Time
+	}
+)
+
+// clientAuthTypes contains the client certificate policy of each saddle.server.tls.client_auth value.
+var clientAuthTypes = map[string]tls.ClientAuthType{
+	"":                   tls.NoClientCert,
+	"none":               tls.NoClientCert,
+	"request":            tls.RequestClientCert,
+	"require":            tls.RequireAnyClientCert,
+	"verify-if-given":    tls.VerifyClientCertIfGiven,
+	"require-and-verify": tls.RequireAndVerifyClientCert,
+}
+
+// tlsVersions contains the TLS version of each saddle.server.tls.min_version value.
+var tlsVersions = map[string]uint16{
+	"":    tls.VersionTLS12,
+	"1.2": tls.VersionTLS12,
+	"1.3": tls.VersionTLS13,
+}
+
+// serverTLSConfig constructs the TLS config of the listener from the referenced configuration; nil when the listener
+// is plaintext.
+func serverTLSConfig(config *models.ServerTLS, logger *log.Logger) (*tls.Config, error) {
+	if config == nil {
+		return nil, nil
+	}
+
+	cr := &certReloader{
+		certFile: config.CertFile,
+		keyFile:  config.KeyFile,
+		logger:   logger,
+	}
+	if err := cr.load(); err != nil {
+		return nil, err
+	}
+
+	tc := &tls.Config{
+		ClientAuth:     clientAuthTypes[config.ClientAuth],
+		GetCertificate: cr.getCertificate,
+		MinVersion:     tlsVersions[config.MinVersion],
+	}
+
+	// - load certificate authority of client certificate(s) ↴
+	if tc.ClientAuth >= tls.VerifyClientCertIfGiven && config.ClientCAFile == "" {
+		return nil, fmt.Errorf("client_auth %s requires a client_ca_file", config.ClientAuth)
+	}
+	if config.ClientCAFile != "" {
+		ca, err := os.ReadFile(config.ClientCAFile)
+		if err != nil {
+			return nil, err
+		}
+		tc.ClientCAs = x509.NewCertPool()
+		if !tc.ClientCAs.AppendCertsFromPEM(ca) {
+			return nil, fmt.Errorf("no certificate(s) found within %s", config.ClientCAFile)
+		}
+	}
+	return tc, nil
+}
+
+// getCertificate returns the certificate of the listener, reloading it first when the file(s) were rotated; the
+// current certificate is kept should a rotated certificate fail to load.
+func (cr *certReloader) getCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	if time.Since(cr.checkedAt) >= certCheckInterval {
+		cr.checkedAt = time.Now()
+		if mt, err := cr.lastModified(); err == nil && !mt.Equal(cr.modTime) {
+			if err := cr.reload(mt); err != nil {
+				cr.logger.Error("unable to reload TLS certificate; keeping current certificate",
+					zap.String("cert_file", cr.certFile),
+					zap.Error(err),
+				)
+			} else {
+				cr.logger.Info("reloaded TLS certificate",
+					zap.String("cert_file", cr.certFile),
+				)
+			}
+		}
+	}
+	return cr.cert, nil
+}
+
+// load loads the certificate of the listener.
+func (cr *certReloader) load() error {
+	cr.mu.Lock()
+	defer cr.mu.Unlock()
+
+	mt, err := cr.lastModified()
+	if err != nil {
+		return err
+	}
+	cr.checkedAt = time.Now()
+	return cr.reload(mt)
+}
+
+// reload loads the certificate | key file(s), recording the referenced modification time on success.
+func (cr *certReloader) reload(modTime time.Time) error {
+	cert, err := tls.LoadX509KeyPair(cr.certFile, cr.keyFile)
+	if err != nil {
+		return err
+	}
+	cr.cert, cr.modTime = &cert, modTime
+	return nil
+}
+
+// lastModified returns the latest modification time of the certificate | key file(s).
+func (cr *certReloader) lastModified() (time.Time, error) {
+	var latest time.Time
+	for _, f := range []string{cr.certFile, cr.keyFile} {
+		fi, err := os.Stat(f)
+		if err != nil {
+			return time.Time{}, err
+		}
+		if fi.ModTime().After(latest) {
+			latest = fi.ModTime()
+		}
+	}
+	return latest, nil
+}
@@ -0,0 +1,249 @@
+package saddle
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/tls"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/captjt/saddle/models"
+	log "github.com/captjt/saddle/pkg/logger"
+)
+
+type (
+	// preforkService contains a service enabling prefork in code, bypassing the validation of saddle.server.
+	preforkService struct {
+		*lifecycleService
+	}
+)
+
+func (s *preforkService) ConfigureFiber(fc *fiber.Config) {
+	fc.DisableStartupMessage, fc.Prefork = true, true
+}
+
+// writeCert writes a self-signed certificate of the referenced common name along with its key to cert.pem | key.pem
+// within the referenced folder, returning their path(s).
+func writeCert(t *testing.T, dir, name string) (string, string) {
+	t.Helper()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	template := &x509.Certificate{
+		SerialNumber:          big.NewInt(time.Now().UnixNano()),
+		Subject:               pkix.Name{CommonName: name},
+		NotBefore:             time.Now().Add(-time.Hour),
+		NotAfter:              time.Now().Add(time.Hour),
+		IsCA:                  true,
+		BasicConstraintsValid: true,
+		KeyUsage:              x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatal(err)
+	}
+	kb, err := x509.MarshalECPrivateKey(key)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	if err := os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: kb}), 0o600); err != nil {
+		t.Fatal(err)
+	}
+	return certFile, keyFile
+}
+
+// rotate moves the modification time of the referenced file(s) forward so the rotation is observed.
+func rotate(t *testing.T, files ...string) {
+	t.Helper()
+
+	mt := time.Now().Add(time.Minute)
+	for _, f := range files {
+		if err := os.Chtimes(f, mt, mt); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// commonName returns the common name of the referenced certificate.
+func commonName(t *testing.T, cert *tls.Certificate) string {
+	t.Helper()
+
+	c, err := x509.ParseCertificate(cert.Certificate[0])
+	if err != nil {
+		t.Fatal(err)
+	}
+	return c.Subject.CommonName
+}
+
+func TestCertReloaderRotation(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir, "v1")
+
+	cr := &certReloader{certFile: certFile, keyFile: keyFile, logger: log.New(log.Production, "svc")}
+	if err := cr.load(); err != nil {
+		t.Fatalf("load: %v", err)
+	}
+
+	tests := []struct {
+		name   string
+		rotate func()
+		want   string
+	}{
+		{
+			name:   "unchanged",
+			rotate: func() {},
+			want:   "v1",
+		},
+		{
+			name: "rotated",
+			rotate: func() {
+				writeCert(t, dir, "v2")
+				rotate(t, certFile, keyFile)
+			},
+			want: "v2",
+		},
+		{
+			// a half-written rotation must not take the listener down
+			name: "rotated to an invalid certificate",
+			rotate: func() {
+				if err := os.WriteFile(certFile, []byte("not a certificate"), 0o600); err != nil {
+					t.Fatal(err)
+				}
+				rotate(t, certFile)
+			},
+			want: "v2",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.rotate()
+			cr.mu.Lock()
+			cr.checkedAt = time.Time{} // skip the check interval
+			cr.mu.Unlock()
+
+			cert, err := cr.getCertificate(nil)
+			if err != nil {
+				t.Fatalf("getCertificate: %v", err)
+			}
+			if got := commonName(t, cert); got != tt.want {
+				t.Errorf("certificate = %q; want %q", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestServerTLSConfig(t *testing.T) {
+	dir := t.TempDir()
+	certFile, keyFile := writeCert(t, dir, "svc")
+
+	tests := []struct {
+		name           string
+		config         *models.ServerTLS
+		wantNil        bool
+		wantErr        string
+		wantClientAuth tls.ClientAuthType
+		wantMinVersion uint16
+	}{
+		{
+			name:    "plaintext",
+			wantNil: true,
+		},
+		{
+			name:           "defaults",
+			config:         &models.ServerTLS{CertFile: certFile, KeyFile: keyFile},
+			wantClientAuth: tls.NoClientCert,
+			wantMinVersion: tls.VersionTLS12,
+		},
+		{
+			name: "mutual TLS",
+			config: &models.ServerTLS{
+				CertFile:     certFile,
+				KeyFile:      keyFile,
+				ClientCAFile: certFile,
+				ClientAuth:   "require-and-verify",
+				MinVersion:   "1.3",
+			},
+			wantClientAuth: tls.RequireAndVerifyClientCert,
+			wantMinVersion: tls.VersionTLS13,
+		},
+		{
+			name:    "verification without client certificate authority",
+			config:  &models.ServerTLS{CertFile: certFile, KeyFile: keyFile, ClientAuth: "verify-if-given"},
+			wantErr: "requires a client_ca_file",
+		},
+		{
+			name:    "client certificate authority without certificate(s)",
+			config:  &models.ServerTLS{CertFile: certFile, KeyFile: keyFile, ClientCAFile: keyFile},
+			wantErr: "no certificate(s) found",
+		},
+		{
+			name:    "missing key file",
+			config:  &models.ServerTLS{CertFile: certFile, KeyFile: filepath.Join(dir, "missing.pem")},
+			wantErr: "no such file",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tc, err := serverTLSConfig(tt.config, log.New(log.Production, "svc"))
+			if tt.wantErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+					t.Fatalf("error = %v; want %q", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("serverTLSConfig: %v", err)
+			}
+			if tt.wantNil {
+				if tc != nil {
+					t.Errorf("config = %+v; want nil", tc)
+				}
+				return
+			}
+
+			if tc.ClientAuth != tt.wantClientAuth {
+				t.Errorf("client auth = %v; want %v", tc.ClientAuth, tt.wantClientAuth)
+			}
+			if tc.MinVersion != tt.wantMinVersion {
+				t.Errorf("min version = %x; want %x", tc.MinVersion, tt.wantMinVersion)
+			}
+			if tt.config.ClientCAFile != "" && tc.ClientCAs == nil {
+				t.Error("client certificate authority was not loaded")
+			}
+		})
+	}
+}
+
+func TestServePreforkTLS(t *testing.T) {
+	certFile, keyFile := writeCert(t, t.TempDir(), "svc")
+
+	svc := &preforkService{lifecycleService: newLifecycleService()}
+	p, err := testProject(t, svc, "saddle: {server: {tls: {cert_file: "+certFile+", key_file: "+keyFile+"}}}")
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	defer p.stop()
+
+	if err := p.serve(); err == nil || !strings.Contains(err.Error(), "prefork is not supported with TLS") {
+		t.Errorf("serve error = %v; want prefork | TLS error", err)
+	}
+}
@@ -0,0 +1,59 @@
+package saddle
+
+import (
+	"reflect"
+	"strings"
+
+	"github.com/go-playground/validator/v10"
+
+	"github.com/captjt/saddle/models"
+)
+
+// excludedWithTag contains the tag reported for mutually exclusive configuration block(s).
+const excludedWithTag = "excluded_with"
+
+// newValidator constructs the validator of loaded configuration(s); field(s) are named by their mapstructure tags so
+// problem(s) reference the keys of the configuration file(s). Mutual exclusion of optional (pointer) block(s) is
+// enforced by struct-level validation(s), as the excluded_with tag is not evaluated on struct field(s).
+func newValidator() *validator.Validate {
+	v := validator.New()
+	v.RegisterTagNameFunc(mapstructureName)
+	v.RegisterStructValidation(validateExporters, models.Config{}.Saddle)
+	v.RegisterStructValidation(validateOTLP, models.OTLP{})
+	return v
+}
+
+// validateExporters reports every configured trace exporter when more than one is configured; at most one of
+// cloud_trace, jaeger, otlp, stdout and none may be set.
+func validateExporters(sl validator.StructLevel) {
+	sc := sl.Current()
+
+	var set []reflect.StructField
+	for _, name := range []string{"CloudTrace", "Jaeger", "OTLP", "StdOut", "None"} {
+		if f, ok := sc.Type().FieldByName(name); ok && !sc.FieldByName(name).IsNil() {
+			set = append(set, f)
+		}
+	}
+	if len(set) < 2 {
+		return
+	}
+
+	for _, f := range set {
+		var others []string
+		for _, o := range set {
+			if o.Name != f.Name {
+				others = append(others, mapstructureName(o))
+			}
+		}
+		sl.ReportError(sc.FieldByName(f.Name).Interface(), mapstructureName(f), f.Name, excludedWithTag,
+			strings.Join(others, " "))
+	}
+}
+
+// validateOTLP reports the transport security configuration of the OTLP exporter when transport security is disabled.
+func validateOTLP(sl validator.StructLevel) {
+	o := sl.Current().Interface().(models.OTLP)
+	if o.Insecure && o.TLS != nil {
+		sl.ReportError(o.TLS, "tls", "TLS", excludedWithTag, "insecure")
+	}
+}
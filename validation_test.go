@@ -0,0 +1,87 @@
+package saddle
+
+import (
+	"reflect"
+	"testing"
+)
+
+func TestValidateExporters(t *testing.T) {
+	tests := []struct {
+		name   string
+		config string
+		want   map[string]string
+	}{
+		{
+			name:   "no exporter",
+			config: `saddle: {}`,
+			want:   map[string]string{},
+		},
+		{
+			name:   "single exporter",
+			config: `saddle: {stdout: {sample_rate: 100}}`,
+			want:   map[string]string{},
+		},
+		{
+			name: "two exporters",
+			config: `saddle:
+  jaeger: {uri: "http://localhost:4318", sample_rate: 100}
+  stdout: {sample_rate: 100}`,
+			want: map[string]string{
+				"saddle.jaeger": excludedWithTag,
+				"saddle.stdout": excludedWithTag,
+			},
+		},
+		{
+			name: "three exporters",
+			config: `saddle:
+  jaeger: {uri: "http://localhost:4318", sample_rate: 100}
+  otlp: {endpoint: "http://localhost:4318", sample_rate: 100}
+  stdout: {sample_rate: 100}`,
+			want: map[string]string{
+				"saddle.jaeger": excludedWithTag,
+				"saddle.otlp":   excludedWithTag,
+				"saddle.stdout": excludedWithTag,
+			},
+		},
+		{
+			name: "exporter and none",
+			config: `saddle:
+  none: {disabled: true}
+  stdout: {sample_rate: 100}`,
+			want: map[string]string{
+				"saddle.none":   excludedWithTag,
+				"saddle.stdout": excludedWithTag,
+			},
+		},
+		{
+			name: "otlp tls",
+			config: `saddle:
+  otlp: {endpoint: "https://localhost:4318", sample_rate: 100, tls: {server_name: collector}}`,
+			want: map[string]string{},
+		},
+		{
+			name: "otlp insecure",
+			config: `saddle:
+  otlp: {endpoint: "http://localhost:4318", sample_rate: 100, insecure: true}`,
+			want: map[string]string{},
+		},
+		{
+			name: "otlp insecure with tls",
+			config: `saddle:
+  otlp: {endpoint: "http://localhost:4318", sample_rate: 100, insecure: true, tls: {server_name: collector}}`,
+			want: map[string]string{
+				"saddle.otlp.tls": excludedWithTag,
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, ce := testLoad(t, map[string]string{"test.yaml": tt.config}, "test", &testConfig{})
+
+			if got := problemKeys(ce); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("problems = %v; want %v (%v)", got, tt.want, ce)
+			}
+		})
+	}
+}
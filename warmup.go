@@ -0,0 +1,132 @@
+package saddle
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/captjt/saddle/models"
+)
+
+type (
+	// warmUpTask contains a warm-up task registered by a service (see Project.RegisterWarmUp).
+	warmUpTask struct {
+		name   string
+		status *models.WarmUpStatus
+		task   func(context.Context) error
+	}
+
+	// startup contains the warm-up task(s) registered by a service along with their progress; the service is started
+	// once it attached and every warm-up task finished successfully.
+	startup struct {
+		mu      sync.RWMutex
+		started bool
+		tasks   []*warmUpTask
+	}
+)
+
+// RegisterWarmUp registers a warm-up task (e.g. loading lookup tables) run once the listener is up; non-admin route(s)
+// respond 503 with a Retry-After header until every warm-up task finished successfully, and a failed task stops the
+// service. The context of a task is cancelled once saddle.lifecycle.warm_up_timeout elapses or shutdown begins. Tasks
+// must be registered before the listener is up (i.e. during Bind, Attach or Start).
+func (p *Project[T]) RegisterWarmUp(name string, task func(ctx context.Context) error) {
+	p.startup.mu.Lock()
+	defer p.startup.mu.Unlock()
+
+	p.startup.tasks = append(p.startup.tasks, &warmUpTask{
+		name:   name,
+		status: &models.WarmUpStatus{Name: name, Status: models.WarmUpPending},
+		task:   task,
+	})
+}
+
+// warmUp runs the registered warm-up task(s) concurrently within saddle.lifecycle.warm_up_timeout, marking the project
+// started once every task finished successfully.
+func (p *Project[T]) warmUp() error {
+	p.mu.RLock()
+	timeout := p.loaded.config.Saddle.Lifecycle.WarmUpTimeout
+	p.mu.RUnlock()
+
+	p.startup.mu.RLock()
+	tasks := p.startup.tasks
+	p.startup.mu.RUnlock()
+
+	errs := make([]error, len(tasks))
+	var wg sync.WaitGroup
+	for i, t := range tasks {
+		wg.Add(1)
+		go func(i int, t *warmUpTask) {
+			defer wg.Done()
+
+			p.setWarmUpStatus(t, models.WarmUpRunning, "", 0)
+			start := time.Now()
+			if err := runHook(p.ctx, timeout, t.task); err != nil {
+				errs[i] = fmt.Errorf("warm-up %s: %w", t.name, err)
+				p.setWarmUpStatus(t, models.WarmUpFailed, p.logger.Redacted(err.Error()), time.Since(start))
+				return
+			}
+			p.setWarmUpStatus(t, models.WarmUpDone, "", time.Since(start))
+			p.logger.Info("warm-up finished",
+				zap.String("warm_up", t.name),
+				zap.Duration("duration", time.Since(start)),
+			)
+		}(i, t)
+	}
+	wg.Wait()
+
+	if err := errors.Join(errs...); err != nil {
+		return err
+	}
+
+	p.startup.mu.Lock()
+	p.startup.started = true
+	p.startup.mu.Unlock()
+	return nil
+}
+
+// setWarmUpStatus records the progress of the referenced warm-up task.
+func (p *Project[T]) setWarmUpStatus(t *warmUpTask, status, err string, duration time.Duration) {
+	p.startup.mu.Lock()
+	defer p.startup.mu.Unlock()
+
+	t.status.Status, t.status.Error = status, err
+	if duration > 0 {
+		t.status.Duration = duration.String()
+	}
+}
+
+// isStarted reports whether the project attached and every warm-up task finished successfully.
+func (p *Project[T]) isStarted() bool {
+	p.startup.mu.RLock()
+	defer p.startup.mu.RUnlock()
+
+	return p.startup.started
+}
+
+// startupStatus returns a copy of the startup progress of the project.
+func (p *Project[T]) startupStatus() *models.StartupResponse {
+	p.startup.mu.RLock()
+	defer p.startup.mu.RUnlock()
+
+	resp := &models.StartupResponse{
+		Started: p.startup.started,
+		WarmUps: make([]*models.WarmUpStatus, 0, len(p.startup.tasks)),
+	}
+	for _, t := range p.startup.tasks {
+		s := *t.status
+		resp.WarmUps = append(resp.WarmUps, &s)
+	}
+	return resp
+}
+
+// retryAfter returns the duration request(s) are asked to wait while the project warms up.
+func (p *Project[T]) retryAfter() time.Duration {
+	p.mu.RLock()
+	defer p.mu.RUnlock()
+
+	return p.loaded.config.Saddle.Lifecycle.RetryAfter
+}
@@ -0,0 +1,164 @@
+package saddle
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gofiber/fiber/v2"
+
+	"github.com/captjt/saddle/models"
+)
+
+// get requests the referenced path of the referenced app, returning the response.
+func get(t *testing.T, app *fiber.App, path string) *http.Response {
+	t.Helper()
+
+	resp, err := app.Test(httptest.NewRequest(http.MethodGet, path, nil), 5000)
+	if err != nil {
+		t.Fatalf("request %s: %v", path, err)
+	}
+	t.Cleanup(func() { resp.Body.Close() })
+	return resp
+}
+
+// waitWarmUp waits until the referenced warm-up task of the project reports the referenced status.
+func waitWarmUp(t *testing.T, p *Project[*lifecycleService], status string) {
+	t.Helper()
+
+	for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(10 * time.Millisecond) {
+		if time.Now().After(deadline) {
+			t.Fatalf("warm-up did not report %s", status)
+		}
+		if ws := p.startupStatus().WarmUps; len(ws) > 0 && ws[0].Status == status {
+			return
+		}
+	}
+}
+
+func TestProjectWarmUp(t *testing.T) {
+	tests := []struct {
+		name  string
+		admin bool
+	}{
+		{name: "without admin listener"},
+		{
+			// probe(s) are served by the admin listener; the public listener exempts nothing
+			name:  "with admin listener",
+			admin: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			config := `saddle: {lifecycle: {retry_after: 7s}}`
+			if tt.admin {
+				config = `saddle: {lifecycle: {retry_after: 7s}, admin: {address: ` + freeAddress(t) + `}}`
+			}
+			p, err := testProject(t, newLifecycleService(), config)
+			if err != nil {
+				t.Fatalf("new: %v", err)
+			}
+			release := make(chan struct{})
+			p.RegisterWarmUp("tables", func(ctx context.Context) error {
+				<-release
+				return nil
+			})
+
+			served := make(chan error, 1)
+			go func() {
+				served <- p.serve()
+			}()
+			waitWarmUp(t, p, models.WarmUpRunning)
+
+			// - non-admin route(s) are rejected while warming up ↴
+			resp := get(t, p.App, "/v1/users")
+			if resp.StatusCode != http.StatusServiceUnavailable {
+				t.Errorf("status = %d; want %d", resp.StatusCode, http.StatusServiceUnavailable)
+			}
+			if got := resp.Header.Get(fiber.HeaderRetryAfter); got != "7" {
+				t.Errorf("Retry-After = %q; want %q", got, "7")
+			}
+
+			// - probe(s) report the progress of warming up ↴
+			probes := p.App
+			if tt.admin {
+				probes = p.admin
+				if got := get(t, p.App, "/healthz").StatusCode; got != http.StatusServiceUnavailable {
+					t.Errorf("public /healthz status = %d; want %d", got, http.StatusServiceUnavailable)
+				}
+			}
+			if got := get(t, probes, "/healthz").StatusCode; got != http.StatusNoContent {
+				t.Errorf("/healthz status = %d; want %d", got, http.StatusNoContent)
+			}
+			if got := get(t, probes, "/startupz").StatusCode; got != http.StatusServiceUnavailable {
+				t.Errorf("/startupz status = %d; want %d", got, http.StatusServiceUnavailable)
+			}
+			rr := &models.ReadinessResponse{}
+			resp = get(t, probes, "/readyz")
+			if err := json.NewDecoder(resp.Body).Decode(rr); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.StatusCode != http.StatusServiceUnavailable || rr.Ready {
+				t.Errorf("/readyz status = %d, ready = %t; want %d, false", resp.StatusCode, rr.Ready,
+					http.StatusServiceUnavailable)
+			}
+
+			// - route(s) are served once warmed up ↴
+			close(release)
+			waitWarmUp(t, p, models.WarmUpDone)
+			for deadline := time.Now().Add(5 * time.Second); !p.isStarted(); time.Sleep(10 * time.Millisecond) {
+				if time.Now().After(deadline) {
+					t.Fatal("project did not start")
+				}
+			}
+			sr := &models.StartupResponse{}
+			resp = get(t, probes, "/startupz")
+			if err := json.NewDecoder(resp.Body).Decode(sr); err != nil {
+				t.Fatalf("decode: %v", err)
+			}
+			if resp.StatusCode != http.StatusOK || !sr.Started || sr.WarmUps[0].Status != models.WarmUpDone {
+				t.Errorf("/startupz status = %d, response = %+v; want %d, started", resp.StatusCode, sr, http.StatusOK)
+			}
+			if got := get(t, p.App, "/v1/users").StatusCode; got != http.StatusNotFound {
+				t.Errorf("status = %d; want %d", got, http.StatusNotFound)
+			}
+
+			if err := p.stop(); err != nil {
+				t.Errorf("stop: %v", err)
+			}
+			if err := <-served; err != nil {
+				t.Errorf("serve: %v", err)
+			}
+		})
+	}
+}
+
+func TestProjectWarmUpFails(t *testing.T) {
+	p, err := testProject(t, newLifecycleService(), `name: svc`)
+	if err != nil {
+		t.Fatalf("new: %v", err)
+	}
+	defer p.stop()
+
+	p.RegisterWarmUp("tables", func(context.Context) error {
+		return errors.New("failed")
+	})
+
+	// a failed warm-up task stops serving
+	if err := p.serve(); err == nil || !strings.Contains(err.Error(), "warm-up tables: failed") {
+		t.Errorf("serve error = %v; want warm-up error", err)
+	}
+	ws := p.startupStatus().WarmUps[0]
+	if ws.Status != models.WarmUpFailed || ws.Error != "failed" {
+		t.Errorf("warm-up = %+v; want failed", ws)
+	}
+	if p.isStarted() {
+		t.Error("project started; want not started")
+	}
+}
@@ -0,0 +1,225 @@
+package saddle
+
+import (
+	"context"
+	"fmt"
+	"runtime/debug"
+	"sync"
+	"time"
+
+	"go.uber.org/zap"
+
+	"github.com/captjt/saddle/models"
+)
+
+const (
+	// RestartNever never restarts a worker once it returns.
+	RestartNever RestartPolicy = "never"
+	// RestartOnFailure restarts a worker, with backoff, when it returns an error or panics.
+	RestartOnFailure RestartPolicy = "on-failure"
+	// RestartAlways restarts a worker, with backoff, whenever it returns.
+	RestartAlways RestartPolicy = "always"
+
+	workerRunning   = "running"
+	workerBackoff   = "backoff"
+	workerCompleted = "completed"
+	workerFailed    = "failed"
+	workerStopped   = "stopped"
+
+	// defaultInitialBackoff contains the delay before a worker is first restarted.
+	defaultInitialBackoff = time.Second
+	// defaultMaxBackoff contains the maximum delay before a worker is restarted.
+	defaultMaxBackoff = 30 * time.Second
+)
+
+type (
+	// Runnable contains a background worker run by a project (see Project.Go); the context is cancelled once shutdown
+	// begins and the worker is expected to return.
+	Runnable func(ctx context.Context) error
+
+	// RestartPolicy contains when a worker is restarted once it returns.
+	RestartPolicy string
+
+	// WorkerOption configures how a worker is run.
+	WorkerOption func(*worker)
+
+	// worker contains a background worker along with its restart policy and state.
+	worker struct {
+		initialBackoff time.Duration
+		maxBackoff     time.Duration
+		policy         RestartPolicy
+		run            Runnable
+		status         models.WorkerStatus
+	}
+
+	// workers contains the background worker(s) run by a project.
+	workers struct {
+		mu      sync.RWMutex
+		running sync.WaitGroup
+		workers []*worker
+	}
+)
+
+// WithRestartPolicy sets the restart policy of a worker; workers are restarted on failure by default.
+func WithRestartPolicy(policy RestartPolicy) WorkerOption {
+	return func(w *worker) {
+		w.policy = policy
+	}
+}
+
+// WithBackoff sets the delay before a worker is first restarted, doubled on each consecutive restart up to the
+// referenced maximum; a worker which returns successfully resets the delay.
+func WithBackoff(initial, max time.Duration) WorkerOption {
+	return func(w *worker) {
+		w.initialBackoff, w.maxBackoff = initial, max
+	}
+}
+
+// Go runs the referenced worker in the background until shutdown begins, restarting it according to its restart
+// policy; a panicking worker is recovered and treated as failed. The state of each worker is reported on /status.
+func (p *Project[T]) Go(name string, run Runnable, opts ...WorkerOption) {
+	w := &worker{
+		initialBackoff: defaultInitialBackoff,
+		maxBackoff:     defaultMaxBackoff,
+		policy:         RestartOnFailure,
+		run:            run,
+	}
+	for _, opt := range opts {
+		opt(w)
+	}
+	w.status = models.WorkerStatus{
+		Name:          name,
+		RestartPolicy: string(w.policy),
+	}
+
+	p.workers.mu.Lock()
+	p.workers.workers = append(p.workers.workers, w)
+	p.workers.mu.Unlock()
+
+	p.workers.running.Add(1)
+	go func() {
+		defer p.workers.running.Done()
+		p.superviseWorker(w)
+	}()
+}
+
+// superviseWorker runs the referenced worker until it is not to be restarted or shutdown begins.
+func (p *Project[T]) superviseWorker(w *worker) {
+	logger := p.logger
+	delay := w.initialBackoff
+
+	for {
+		p.setWorkerState(w, workerRunning, nil)
+		logger.Info("worker started",
+			zap.String("worker", w.status.Name),
+			zap.Int("restarts", w.status.Restarts),
+		)
+		err := p.runWorker(w)
+
+		switch {
+		case p.ctx.Err() != nil:
+			p.setWorkerState(w, workerStopped, err)
+			logger.Info("worker stopped",
+				zap.String("worker", w.status.Name),
+			)
+			return
+		case err == nil && w.policy != RestartAlways:
+			p.setWorkerState(w, workerCompleted, nil)
+			logger.Info("worker completed",
+				zap.String("worker", w.status.Name),
+			)
+			return
+		case err != nil && w.policy == RestartNever:
+			p.setWorkerState(w, workerFailed, err)
+			logger.Error("worker failed; not restarting",
+				zap.String("worker", w.status.Name),
+				zap.Error(err),
+			)
+			return
+		case err == nil:
+			delay = w.initialBackoff
+		}
+
+		p.setWorkerState(w, workerBackoff, err)
+		logger.Warn("worker returned; restarting after backoff",
+			zap.String("worker", w.status.Name),
+			zap.Duration("backoff", delay),
+			zap.Error(err),
+		)
+
+		select {
+		case <-p.ctx.Done():
+			p.setWorkerState(w, workerStopped, err)
+			return
+		case <-time.After(delay):
+		}
+		if delay *= 2; delay > w.maxBackoff {
+			delay = w.maxBackoff
+		}
+
+		p.workers.mu.Lock()
+		w.status.Restarts++
+		p.workers.mu.Unlock()
+	}
+}
+
+// runWorker runs the referenced worker once, recovering any panic as an error.
+func (p *Project[T]) runWorker(w *worker) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			p.logger.Error("worker panicked",
+				zap.String("worker", w.status.Name),
+				zap.Any("panic", r),
+				zap.ByteString("stack", debug.Stack()),
+			)
+			err = fmt.Errorf("panic: %v", r)
+		}
+	}()
+	return w.run(p.ctx)
+}
+
+// setWorkerState records the state of the referenced worker along with the error it last returned, if any.
+func (p *Project[T]) setWorkerState(w *worker, state string, err error) {
+	p.workers.mu.Lock()
+	defer p.workers.mu.Unlock()
+
+	w.status.State = state
+	if state == workerRunning {
+		w.status.StartedAt = time.Now().UTC().Format(time.RFC3339)
+	}
+	if err != nil {
+		w.status.LastError = p.logger.Redacted(err.Error())
+	}
+}
+
+// workerStatus returns a copy of the state of each worker; nil when the project runs none.
+func (p *Project[T]) workerStatus() []*models.WorkerStatus {
+	p.workers.mu.RLock()
+	defer p.workers.mu.RUnlock()
+
+	var statuses []*models.WorkerStatus
+	for _, w := range p.workers.workers {
+		s := w.status
+		statuses = append(statuses, &s)
+	}
+	return statuses
+}
+
+// waitWorkers waits for every worker to return once shutdown began, up to the referenced timeout (zero waits
+// indefinitely).
+func (p *Project[T]) waitWorkers(timeout time.Duration) error {
+	done := make(chan struct{})
+	go func() {
+		p.workers.running.Wait()
+		close(done)
+	}()
+
+	ctx, cancel := deadline(context.Background(), timeout)
+	defer cancel()
+	select {
+	case <-done:
+		return nil
+	case <-ctx.Done():
+		return fmt.Errorf("worker(s) did not return: %w", ctx.Err())
+	}
+}
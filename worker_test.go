@@ -0,0 +1,139 @@
+package saddle
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	log "github.com/captjt/saddle/pkg/logger"
+)
+
+// errPanic makes a scripted worker panic rather than return.
+var errPanic = errors.New("panic")
+
+// workerProject constructs a project running worker(s) only.
+func workerProject() *Project[*testService] {
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Project[*testService]{
+		cancel:  cancel,
+		ctx:     ctx,
+		logger:  log.New(log.Production, "svc"),
+		workers: &workers{},
+	}
+}
+
+func TestProjectGo(t *testing.T) {
+	failed := errors.New("failed")
+
+	tests := []struct {
+		name         string
+		policy       RestartPolicy
+		script       []error
+		want         string
+		wantRestarts int
+		wantErr      string
+	}{
+		{
+			name:    "never restarts a failed worker",
+			policy:  RestartNever,
+			script:  []error{failed},
+			want:    workerFailed,
+			wantErr: "failed",
+		},
+		{
+			name:   "never restarts a completed worker",
+			policy: RestartNever,
+			script: []error{nil},
+			want:   workerCompleted,
+		},
+		{
+			name:         "on-failure restarts a failed | panicking worker",
+			policy:       RestartOnFailure,
+			script:       []error{failed, errPanic, nil},
+			want:         workerCompleted,
+			wantRestarts: 2,
+			wantErr:      "panic: panic",
+		},
+		{
+			name:         "always restarts a completed worker",
+			policy:       RestartAlways,
+			script:       []error{nil, nil},
+			want:         workerStopped,
+			wantRestarts: 2,
+		},
+		{
+			name:   "shutdown stops a running worker",
+			policy: RestartOnFailure,
+			want:   workerStopped,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p := workerProject()
+
+			// - run the scripted outcome(s), then block until shutdown ↴
+			var runs atomic.Int64
+			p.Go("worker", func(ctx context.Context) error {
+				n := int(runs.Add(1))
+				if n > len(tt.script) {
+					<-ctx.Done()
+					return nil
+				}
+				if err := tt.script[n-1]; !errors.Is(err, errPanic) {
+					return err
+				}
+				panic("panic")
+			}, WithRestartPolicy(tt.policy), WithBackoff(time.Millisecond, 2*time.Millisecond))
+
+			// - wait for the worker to return for good or to block, then shut down ↴
+			for deadline := time.Now().Add(5 * time.Second); ; time.Sleep(time.Millisecond) {
+				if time.Now().After(deadline) {
+					t.Fatal("worker did not settle")
+				}
+				s := p.workerStatus()[0]
+				if s.State == workerCompleted || s.State == workerFailed ||
+					s.State == workerRunning && int(runs.Load()) > len(tt.script) {
+					break
+				}
+			}
+			p.cancel()
+			if err := p.waitWorkers(5 * time.Second); err != nil {
+				t.Fatalf("waitWorkers: %v", err)
+			}
+
+			s := p.workerStatus()[0]
+			if s.State != tt.want {
+				t.Errorf("state = %q; want %q", s.State, tt.want)
+			}
+			if s.Restarts != tt.wantRestarts {
+				t.Errorf("restarts = %d; want %d", s.Restarts, tt.wantRestarts)
+			}
+			if s.LastError != tt.wantErr {
+				t.Errorf("last error = %q; want %q", s.LastError, tt.wantErr)
+			}
+			if s.RestartPolicy != string(tt.policy) {
+				t.Errorf("restart policy = %q; want %q", s.RestartPolicy, tt.policy)
+			}
+		})
+	}
+}
+
+func TestProjectWaitWorkers(t *testing.T) {
+	p := workerProject()
+
+	release := make(chan struct{})
+	defer close(release)
+	// a worker ignoring shutdown cannot hold it up beyond the timeout
+	p.Go("stubborn", func(context.Context) error {
+		<-release
+		return nil
+	})
+	p.cancel()
+
+	if err := p.waitWorkers(10 * time.Millisecond); !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("waitWorkers = %v; want %v", err, context.DeadlineExceeded)
+	}
+}